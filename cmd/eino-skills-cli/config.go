@@ -0,0 +1,72 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+
+	skill "github.com/dyike/eino-skills/pkg/skill"
+)
+
+// toggleCmd implements the enable and disable commands, which edit the
+// skills config file.
+func toggleCmd(ctx context.Context, args []string, enable bool) {
+	cmd, done := "disable", "Disabled"
+	if enable {
+		cmd, done = "enable", "Enabled"
+	}
+
+	fs := flag.NewFlagSet(cmd, flag.ExitOnError)
+	configPath := fs.String("config", skill.NewLoader().ConfigFile(), "Skills configuration file")
+	if err := fs.Parse(args); err != nil {
+		fmt.Fprintf(os.Stderr, "Error parsing flags: %v\n", err)
+		os.Exit(1)
+	}
+
+	if fs.NArg() < 1 {
+		fmt.Fprintf(os.Stderr, "Usage: eino-skills %s [--config <file>] <skill-name>...\n", cmd)
+		os.Exit(1)
+	}
+
+	config, err := skill.LoadConfig(*configPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	// Scan without the config so disabled skills are found too
+	loader := skill.NewLoader(skill.WithConfigFile(""), skill.WithLoaderLogger(warnLogger))
+	metadata, err := loader.LoadMetadataOnly(ctx)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error loading skills: %v\n", err)
+		os.Exit(1)
+	}
+
+	for _, name := range fs.Args() {
+		var installed []skill.SkillMetadata
+		for _, m := range metadata {
+			if m.Name == name {
+				installed = append(installed, m)
+			}
+		}
+		if len(installed) == 0 {
+			fmt.Fprintf(os.Stderr, "Warning: skill '%s' is not installed\n", name)
+			installed = append(installed, skill.SkillMetadata{Name: name})
+		}
+
+		for _, m := range installed {
+			if enable {
+				config.Enable(m)
+			} else {
+				config.Disable(m)
+			}
+		}
+	}
+
+	if err := config.Save(*configPath); err != nil {
+		fmt.Fprintf(os.Stderr, "Error writing %s: %v\n", *configPath, err)
+		os.Exit(1)
+	}
+	fmt.Printf("%s %d skill(s) in %s\n", done, fs.NArg(), *configPath)
+}
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+
+	skill "github.com/dyike/eino-skills/pkg/skill"
+)
+
+// exportCmd writes all installed skills to a JSON or tar export.
+func exportCmd(ctx context.Context, args []string) {
+	fs := flag.NewFlagSet("export", flag.ExitOnError)
+	format := fs.String("format", string(skill.ExportJSON), "Export format: json or tar")
+	output := fs.String("o", "-", "Output file, - for stdout")
+	if err := fs.Parse(args); err != nil {
+		fmt.Fprintf(os.Stderr, "Error parsing flags: %v\n", err)
+		os.Exit(1)
+	}
+
+	registry := skill.NewRegistry(skill.NewLoader(skill.WithLoaderLogger(warnLogger)))
+	if err := registry.Initialize(ctx); err != nil {
+		fmt.Fprintf(os.Stderr, "Error loading skills: %v\n", err)
+		os.Exit(1)
+	}
+
+	if *output == "-" {
+		if err := registry.Export(ctx, os.Stdout, skill.ExportFormat(*format)); err != nil {
+			fmt.Fprintf(os.Stderr, "Error exporting skills: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	if err := exportFile(ctx, registry, *output, skill.ExportFormat(*format)); err != nil {
+		fmt.Fprintf(os.Stderr, "Error exporting skills: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Printf("Exported %d skill(s) to %s\n", registry.Count(), *output)
+}
+
+// exportFile writes an export to a file. Closing the file is checked, since
+// a failed write-back may only be reported there.
+func exportFile(ctx context.Context, registry *skill.Registry, file string, format skill.ExportFormat) error {
+	f, err := os.Create(file)
+	if err != nil {
+		return err
+	}
+	if err := registry.Export(ctx, f, format); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// importCmd extracts an export into skill directories.
+func importCmd(_ context.Context, args []string) {
+	fs := flag.NewFlagSet("import", flag.ExitOnError)
+	if err := fs.Parse(args); err != nil {
+		fmt.Fprintf(os.Stderr, "Error parsing flags: %v\n", err)
+		os.Exit(1)
+	}
+
+	if fs.NArg() < 2 {
+		fmt.Fprintf(os.Stderr, "Usage: eino-skills import <export-file|-> <dir>\n")
+		os.Exit(1)
+	}
+
+	var r io.Reader = os.Stdin
+	if fs.Arg(0) != "-" {
+		f, err := os.Open(fs.Arg(0))
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		defer f.Close()
+		r = f
+	}
+
+	exp, err := skill.ReadExport(r)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	dir := fs.Arg(1)
+	if err := exp.Extract(dir); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("Imported %d skill version(s) from generation %d into %s\n", len(exp.Skills), exp.Generation, dir)
+	fmt.Printf("  Global skills:  %s\n", filepath.Join(dir, string(skill.SourceGlobal)))
+	fmt.Printf("  Project skills: %s\n", filepath.Join(dir, string(skill.SourceProject)))
+	for _, name := range slices.Sorted(maps.Keys(exp.Pins)) {
+		fmt.Printf("  Pin %s to %s to reproduce the exported defaults\n", name, exp.Pins[name])
+	}
+}
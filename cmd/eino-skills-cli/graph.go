@@ -0,0 +1,43 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+
+	skill "github.com/dyike/eino-skills/pkg/skill"
+)
+
+// graphCmd prints the skill dependency graph in DOT format and reports
+// missing dependencies and cycles.
+func graphCmd(ctx context.Context, args []string) {
+	fs := flag.NewFlagSet("graph", flag.ExitOnError)
+	if err := fs.Parse(args); err != nil {
+		fmt.Fprintf(os.Stderr, "Error parsing flags: %v\n", err)
+		os.Exit(1)
+	}
+
+	// Dependency problems are reported below rather than logged
+	registry := skill.NewRegistry(skill.NewLoader(skill.WithLoaderLogger(warnLogger)),
+		skill.WithRegistryLogger(slog.New(slog.DiscardHandler)))
+	if err := registry.Initialize(ctx); err != nil {
+		fmt.Fprintf(os.Stderr, "Error loading skills: %v\n", err)
+		os.Exit(1)
+	}
+
+	graph := registry.Dependencies()
+	fmt.Print(graph.DOT())
+
+	for _, m := range graph.Missing {
+		fmt.Fprintf(os.Stderr, "Error: skill '%s' depends on '%s', which is not installed\n", m.Skill, m.Ref)
+	}
+	for _, cycle := range graph.Cycles {
+		fmt.Fprintf(os.Stderr, "Error: dependency cycle: %s\n", strings.Join(cycle, " -> "))
+	}
+	if len(graph.Missing) > 0 || len(graph.Cycles) > 0 {
+		os.Exit(1)
+	}
+}
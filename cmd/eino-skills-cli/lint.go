@@ -0,0 +1,228 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+
+	skill "github.com/dyike/eino-skills/pkg/skill"
+)
+
+func lintCmd(ctx context.Context, args []string) {
+	fs := flag.NewFlagSet("lint", flag.ExitOnError)
+	format := fs.String("format", "text", "Output format: text, json or sarif")
+	configPath := fs.String("config", "", "Lint configuration file (YAML)")
+	disable := fs.String("disable", "", "Comma-separated rules to disable")
+	failOn := fs.String("fail-on", "error", "Exit non-zero on: error, warning or none")
+	listRules := fs.Bool("list-rules", false, "List available rules and exit")
+	if err := fs.Parse(args); err != nil {
+		fmt.Fprintf(os.Stderr, "Error parsing flags: %v\n", err)
+		os.Exit(1)
+	}
+	switch *failOn {
+	case "error", "warning", "none":
+	default:
+		fmt.Fprintf(os.Stderr, "Unknown fail-on severity: %s (want error, warning or none)\n", *failOn)
+		os.Exit(1)
+	}
+
+	config := &skill.LintConfig{}
+	if *configPath != "" {
+		loaded, err := skill.LoadLintConfig(*configPath)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		config = loaded
+	}
+	if config.Rules == nil {
+		config.Rules = make(map[string]skill.LintRuleConfig)
+	}
+	for _, name := range strings.Split(*disable, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			rc := config.Rules[name]
+			rc.Severity = skill.LintSeverityOff
+			config.Rules[name] = rc
+		}
+	}
+
+	linter, err := skill.NewLinter(config)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	if *listRules {
+		printLintRules(linter.Rules())
+		return
+	}
+
+	paths := fs.Args()
+	if len(paths) == 0 {
+		paths = []string{".eino/skills"}
+	}
+
+	diagnostics, err := linter.Lint(ctx, paths)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	switch *format {
+	case "text":
+		err = writeLintText(os.Stdout, diagnostics)
+	case "json":
+		err = writeLintJSON(os.Stdout, diagnostics)
+	case "sarif":
+		err = writeLintSARIF(os.Stdout, linter.Rules(), diagnostics)
+	default:
+		fmt.Fprintf(os.Stderr, "Unknown format: %s\n", *format)
+		os.Exit(1)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error writing output: %v\n", err)
+		os.Exit(1)
+	}
+
+	for _, d := range diagnostics {
+		if *failOn == "warning" || (*failOn == "error" && d.Severity == skill.SeverityError) {
+			os.Exit(1)
+		}
+	}
+}
+
+func printLintRules(rules []skill.LintRule) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "RULE\tSEVERITY\tDESCRIPTION")
+	for _, r := range rules {
+		fmt.Fprintf(w, "%s\t%s\t%s\n", r.Name, r.Severity, r.Description)
+	}
+	if err := w.Flush(); err != nil {
+		fmt.Fprintf(os.Stderr, "Error flushing output: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// writeLintText prints one "file:line: severity: message [rule]" line per diagnostic.
+func writeLintText(w io.Writer, diagnostics []skill.LintDiagnostic) error {
+	errors, warnings := 0, 0
+	for _, d := range diagnostics {
+		location := d.File
+		if d.Line > 0 {
+			location = fmt.Sprintf("%s:%d", d.File, d.Line)
+		}
+		if _, err := fmt.Fprintf(w, "%s: %s: %s [%s]\n", location, d.Severity, d.Message, d.Rule); err != nil {
+			return err
+		}
+		if d.Severity == skill.SeverityError {
+			errors++
+		} else {
+			warnings++
+		}
+	}
+	_, err := fmt.Fprintf(w, "%d error(s), %d warning(s)\n", errors, warnings)
+	return err
+}
+
+// writeLintJSON prints the diagnostics as a JSON array.
+func writeLintJSON(w io.Writer, diagnostics []skill.LintDiagnostic) error {
+	if diagnostics == nil {
+		diagnostics = []skill.LintDiagnostic{}
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(diagnostics)
+}
+
+// SARIF 2.1.0 output, the subset understood by code scanning tools.
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name  string      `json:"name"`
+	Rules []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID               string       `json:"id"`
+	ShortDescription sarifMessage `json:"shortDescription"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           *sarifRegion          `json:"region,omitempty"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifRegion struct {
+	StartLine int `json:"startLine"`
+}
+
+// writeLintSARIF prints the diagnostics as a SARIF 2.1.0 log. Every rule a
+// result can reference is declared, including the load failure rules.
+func writeLintSARIF(w io.Writer, rules []skill.LintRule, diagnostics []skill.LintDiagnostic) error {
+	driver := sarifDriver{Name: "eino-skills-lint"}
+	for _, r := range append(skill.LoadLintRules(), rules...) {
+		driver.Rules = append(driver.Rules, sarifRule{ID: r.Name, ShortDescription: sarifMessage{Text: r.Description}})
+	}
+
+	results := make([]sarifResult, 0, len(diagnostics))
+	for _, d := range diagnostics {
+		loc := sarifPhysicalLocation{ArtifactLocation: sarifArtifactLocation{URI: filepath.ToSlash(d.File)}}
+		if d.Line > 0 {
+			loc.Region = &sarifRegion{StartLine: d.Line}
+		}
+		results = append(results, sarifResult{
+			RuleID:    d.Rule,
+			Level:     string(d.Severity),
+			Message:   sarifMessage{Text: d.Message},
+			Locations: []sarifLocation{{PhysicalLocation: loc}},
+		})
+	}
+
+	log := sarifLog{
+		Schema:  "https://json.schemastore.org/sarif-2.1.0.json",
+		Version: "2.1.0",
+		Runs:    []sarifRun{{Tool: sarifTool{Driver: driver}, Results: results}},
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(log)
+}
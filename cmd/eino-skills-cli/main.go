@@ -5,13 +5,18 @@ import (
 	"context"
 	"flag"
 	"fmt"
+	"log/slog"
 	"os"
 	"path/filepath"
+	"slices"
 	"text/tabwriter"
 
 	skill "github.com/dyike/eino-skills/pkg/skill"
 )
 
+// warnLogger reports skipped or invalid skills on stderr, keeping stdout clean.
+var warnLogger = slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelWarn}))
+
 func main() {
 	if len(os.Args) < 2 {
 		printUsage()
@@ -30,6 +35,18 @@ func main() {
 		viewCmd(ctx, os.Args[2:])
 	case "validate":
 		validateCmd(ctx, os.Args[2:])
+	case "lint":
+		lintCmd(ctx, os.Args[2:])
+	case "enable":
+		toggleCmd(ctx, os.Args[2:], true)
+	case "disable":
+		toggleCmd(ctx, os.Args[2:], false)
+	case "graph":
+		graphCmd(ctx, os.Args[2:])
+	case "export":
+		exportCmd(ctx, os.Args[2:])
+	case "import":
+		importCmd(ctx, os.Args[2:])
 	case "help":
 		printUsage()
 	default:
@@ -50,6 +67,12 @@ Commands:
   create    Create a new skill from template
   view      View a skill's contents
   validate  Validate a skill's structure
+  lint      Check many skills against configurable rules (text, json or sarif output)
+  enable    Enable skills in the skills config (.eino/skills.yaml)
+  disable   Disable skills in the skills config (.eino/skills.yaml)
+  graph     Print the skill dependency graph in DOT format
+  export    Export all skills with their content to a JSON or tar file
+  import    Extract an export into skill directories
 
 Options:
   --global    Use global skills directory (~/.eino/agent/skills)
@@ -60,7 +83,13 @@ Examples:
   eino-skills list --project
   eino-skills create my-skill
   eino-skills view git-commit
-  eino-skills validate ./skills/my-skill`)
+  eino-skills validate ./skills/my-skill
+  eino-skills lint --format sarif .eino/skills ~/.eino/agent/skills
+  eino-skills lint --disable when-to-use,content-length ./skills/my-skill
+  eino-skills disable noisy-skill
+  eino-skills graph | dot -Tsvg > skills.svg
+  eino-skills export --format tar -o skills.tar
+  eino-skills import skills.tar ./eval-skills`)
 }
 
 func listCmd(ctx context.Context, args []string) {
@@ -75,6 +104,7 @@ func listCmd(ctx context.Context, args []string) {
 	loader := skill.NewLoader(
 		skill.WithGlobalSkillsDir("~/.claude/skills"),
 		skill.WithProjectSkillsDir(".eino/skills"),
+		skill.WithLoaderLogger(warnLogger),
 	)
 
 	var skills []*skill.Skill
@@ -126,6 +156,9 @@ func listCmd(ctx context.Context, args []string) {
 
 	for _, s := range skills {
 		desc := s.Description
+		if s.Deprecated {
+			desc = "(deprecated) " + desc
+		}
 		if len(desc) > 60 {
 			desc = desc[:57] + "..."
 		}
@@ -138,6 +171,38 @@ func listCmd(ctx context.Context, args []string) {
 		fmt.Fprintf(os.Stderr, "Error flushing output: %v\n", err)
 		os.Exit(1)
 	}
+
+	warnDeprecatedReferences(skills)
+}
+
+// warnDeprecatedReferences reports deprecated skills and every reference to
+// them in the other skills on stderr.
+func warnDeprecatedReferences(skills []*skill.Skill) {
+	var deprecated []skill.SkillMetadata
+	for _, s := range skills {
+		if s.Deprecated {
+			deprecated = append(deprecated, s.ToMetadata())
+			if s.ReplacedBy != "" {
+				fmt.Fprintf(os.Stderr, "Warning: skill '%s' is deprecated, use '%s' instead\n", s.Name, s.ReplacedBy)
+			} else {
+				fmt.Fprintf(os.Stderr, "Warning: skill '%s' is deprecated\n", s.Name)
+			}
+		}
+	}
+	if len(deprecated) == 0 {
+		return
+	}
+
+	for _, s := range skills {
+		data, err := os.ReadFile(s.SkillMDPath())
+		if err != nil {
+			continue
+		}
+		others := slices.DeleteFunc(slices.Clone(deprecated), func(m skill.SkillMetadata) bool { return m.Name == s.Name })
+		for _, ref := range skill.FindDeprecatedReferences(string(data), others) {
+			fmt.Fprintf(os.Stderr, "Warning: %s:%d: skill '%s' %s\n", s.SkillMDPath(), ref.Line, s.Name, ref)
+		}
+	}
 }
 
 func createCmd(ctx context.Context, args []string) {
@@ -269,9 +334,14 @@ func viewCmd(ctx context.Context, args []string) {
 	}
 
 	name := fs.Arg(0)
-	loader := skill.NewLoader()
+	// Load through a registry so derived skills are merged with their base
+	registry := skill.NewRegistry(skill.NewLoader(skill.WithLoaderLogger(warnLogger)))
+	if err := registry.Initialize(ctx); err != nil {
+		fmt.Fprintf(os.Stderr, "Error loading skills: %v\n", err)
+		os.Exit(1)
+	}
 
-	s, err := loader.LoadSkill(ctx, name)
+	s, err := registry.Get(ctx, name)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error loading skill '%s': %v\n", name, err)
 		os.Exit(1)
@@ -289,6 +359,9 @@ func viewCmd(ctx context.Context, args []string) {
 		fmt.Printf("Name: %s\n", s.Name)
 		fmt.Printf("Source: %s\n", s.Source)
 		fmt.Printf("Path: %s\n", s.Path)
+		if s.Extends != "" {
+			fmt.Printf("Extends: %s\n", s.Extends)
+		}
 		fmt.Printf("Description: %s\n\n", s.Description)
 		fmt.Println("Content:")
 		fmt.Println("--------")
@@ -351,5 +424,34 @@ func validateCmd(ctx context.Context, args []string) {
 		}
 	}
 
+	// Check links, bundled file references and scripts
+	s, err := skill.NewLoader().LoadSkillFromDir(ctx, skillPath, skill.SourceProject)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ Failed to load skill files: %v\n", err)
+		os.Exit(1)
+	}
+	issues, err := skill.CheckReferences(s)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ Reference check failed: %v\n", err)
+		os.Exit(1)
+	}
+
+	failed := false
+	for _, issue := range issues {
+		if issue.Severity == skill.SeverityError {
+			failed = true
+			fmt.Fprintf(os.Stderr, "❌ %s\n", issue)
+		} else {
+			fmt.Printf("⚠ %s\n", issue)
+		}
+	}
+	if failed {
+		fmt.Fprintln(os.Stderr, "\n❌ Skill validation failed: broken references")
+		os.Exit(1)
+	}
+	if len(issues) == 0 {
+		fmt.Println("✓ Links and bundled file references resolve")
+	}
+
 	fmt.Println("\n✓ Skill validation passed")
 }
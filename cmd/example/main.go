@@ -32,7 +32,7 @@ type LoggerCallback struct {
 
 func (cb *LoggerCallback) OnStart(ctx context.Context, info *callbacks.RunInfo, input callbacks.CallbackInput) context.Context {
 	// 打印 run_terminal_command 或其他工具的输入
-	if info.Name == "run_terminal_command" || info.Name == "list_skills" || info.Name == "view_skill" {
+	if info.Name == "run_terminal_command" || info.Name == "list_skills" || info.Name == "view_skill" || info.Name == "search_skills" {
 		inputStr, _ := json.MarshalIndent(input, "", "  ")
 		fmt.Printf("\n [%s] 👉 Input: %s\n", info.Name, string(inputStr))
 	}
@@ -41,7 +41,7 @@ func (cb *LoggerCallback) OnStart(ctx context.Context, info *callbacks.RunInfo,
 
 func (cb *LoggerCallback) OnEnd(ctx context.Context, info *callbacks.RunInfo, output callbacks.CallbackOutput) context.Context {
 	// 打印工具执行结果
-	if info.Name == "run_terminal_command" || info.Name == "list_skills" || info.Name == "view_skill" {
+	if info.Name == "run_terminal_command" || info.Name == "list_skills" || info.Name == "view_skill" || info.Name == "search_skills" {
 		outputStr, _ := json.MarshalIndent(output, "", "  ")
 		fmt.Printf("✅ [%s] Output: %s\n", info.Name, string(outputStr))
 	}
@@ -108,6 +108,22 @@ func main() {
 		return
 	}
 
+	// 打印热重载带来的 skill 变更
+	registry.Subscribe(func(e skillpkg.Event) {
+		switch e.Type {
+		case skillpkg.EventSkillAdded:
+			fmt.Printf("➕ Skill added: %s %s\n", e.Name, e.NewVersion)
+		case skillpkg.EventSkillRemoved:
+			fmt.Printf("➖ Skill removed: %s\n", e.Name)
+		case skillpkg.EventSkillUpdated:
+			fmt.Printf("🔄 Skill updated: %s %s -> %s\n", e.Name, e.OldVersion, e.NewVersion)
+		case skillpkg.EventSkillInvalid:
+			fmt.Printf("⚠️ Invalid skill %s: %v\n", e.Metadata.Path, e.Err)
+		case skillpkg.EventReloadFailed:
+			fmt.Printf("❌ Failed to reload skills: %v\n", e.Err)
+		}
+	})
+
 	// 2. 创建 Skills 中间件
 	skillsMiddleware := skillsmw.NewSkillsMiddleware(registry)
 
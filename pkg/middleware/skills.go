@@ -16,35 +16,42 @@ import (
 // SkillsMiddleware injects skills metadata into agent prompts
 // and provides skill-related tools.
 type SkillsMiddleware struct {
-	registry *skillpkg.Registry
-	tools    []tool.BaseTool
+	registry  skillpkg.Catalog
+	tools     []tool.BaseTool
+	toolNames []string
 }
 
 // NewSkillsMiddleware creates a new skills middleware.
-func NewSkillsMiddleware(registry *skillpkg.Registry) *SkillsMiddleware {
+// Pass a Registry.View to expose only a subset of the skills.
+func NewSkillsMiddleware(registry skillpkg.Catalog) *SkillsMiddleware {
 	mw := &SkillsMiddleware{
 		registry: registry,
 		tools:    skilltools.NewSkillTools(registry),
 	}
 
+	// The prompt instructions refer to the tools by their registered names
+	for _, t := range mw.tools {
+		if info, err := t.Info(context.Background()); err == nil {
+			mw.toolNames = append(mw.toolNames, info.Name)
+		}
+	}
+
 	return mw
 }
 
 // InjectPrompt adds skills information to the system prompt.
 func (m *SkillsMiddleware) InjectPrompt(basePrompt string) string {
-	skillsSection := m.registry.GenerateSystemPromptSection()
-	instructions := m.registry.GenerateSkillsInstructions()
-
-	if skillsSection == "" {
+	prompt := m.registry.RenderPrompt(m.toolNames)
+	if prompt.Skills == "" {
 		return basePrompt
 	}
 
 	var sb strings.Builder
 	sb.WriteString(basePrompt)
 	sb.WriteString("\n\n")
-	sb.WriteString(skillsSection)
+	sb.WriteString(prompt.Skills)
 	sb.WriteString("\n")
-	sb.WriteString(instructions)
+	sb.WriteString(prompt.Instructions)
 
 	return sb.String()
 }
@@ -67,13 +74,19 @@ func (m *SkillsMiddleware) ProcessMessages(ctx context.Context, messages []*sche
 		return messages
 	}
 
-	// Find potentially relevant skill
+	// Find potentially relevant skill (semantic when the registry has an embedder)
 	content := lastMsg.Content
-	if match := m.registry.FindMatchingSkill(content); match != nil {
+	var match *skillpkg.SkillMetadata
+	if matches, err := m.registry.Search(ctx, content, 1); err != nil {
+		match = m.registry.FindMatchingSkill(content)
+	} else if len(matches) > 0 {
+		match = &matches[0].Metadata
+	}
+	if match != nil {
 		// Add a system hint about the relevant skill
 		hint := &schema.Message{
 			Role:    schema.System,
-			Content: fmt.Sprintf("[Hint: The '%s' skill may be relevant for this task. Consider reading %s/SKILL.md for specialized instructions.]", match.Name, match.Path),
+			Content: fmt.Sprintf("[Hint: The '%s' skill may be relevant for this task. Consider calling %s with name '%s' for specialized instructions.]", match.Name, skillpkg.ToolViewSkill, match.Name),
 		}
 		// Insert hint before the user message
 		result := make([]*schema.Message, 0, len(messages)+1)
@@ -0,0 +1,220 @@
+package skill
+
+import (
+	"container/list"
+	"sync"
+)
+
+// Default content cache limits.
+const (
+	// DefaultCacheEntries is the default maximum number of cached skills
+	DefaultCacheEntries = 256
+
+	// DefaultCacheBytes is the default byte budget (0 means no byte limit)
+	DefaultCacheBytes = 0
+)
+
+// CacheStats reports content cache usage; see Registry.Stats.
+type CacheStats struct {
+	// Hits counts Get calls served from the cache
+	Hits uint64 `json:"hits"`
+
+	// Misses counts Get calls that had to load (or wait for) a skill
+	Misses uint64 `json:"misses"`
+
+	// Loads counts skills actually read from disk
+	Loads uint64 `json:"loads"`
+
+	// SharedLoads counts misses that waited for a concurrent load of the same skill
+	SharedLoads uint64 `json:"shared_loads"`
+
+	// Evictions counts entries dropped to stay within the limits
+	Evictions uint64 `json:"evictions"`
+
+	// Entries is the number of cached skills
+	Entries int `json:"entries"`
+
+	// Bytes is the approximate size of the cached skills
+	Bytes int64 `json:"bytes"`
+
+	// MaxEntries and MaxBytes are the configured limits (0 means unlimited)
+	MaxEntries int   `json:"max_entries"`
+	MaxBytes   int64 `json:"max_bytes"`
+}
+
+// WithCacheSize bounds the content cache to maxEntries skills, evicting the
+// least recently used. Zero or less removes the entry limit.
+// Default: DefaultCacheEntries
+func WithCacheSize(maxEntries int) RegistryOption {
+	return func(r *Registry) {
+		r.cache.maxEntries = max(maxEntries, 0)
+	}
+}
+
+// WithCacheBytes bounds the content cache to an approximate byte budget,
+// evicting the least recently used skills. Zero or less removes the limit.
+// Default: no byte limit
+func WithCacheBytes(maxBytes int64) RegistryOption {
+	return func(r *Registry) {
+		r.cache.maxBytes = max(maxBytes, 0)
+	}
+}
+
+// Stats returns content cache statistics.
+func (r *Registry) Stats() CacheStats {
+	return r.cache.stats()
+}
+
+// skillCache is a size-bounded LRU cache of loaded skills keyed by reference.
+// Pinned entries (stale skills whose files no longer parse) are never
+// evicted, since they cannot be reloaded from disk.
+type skillCache struct {
+	mu         sync.Mutex
+	lru        *list.List // front is most recently used
+	entries    map[string]*list.Element
+	maxEntries int
+	maxBytes   int64
+	bytes      int64
+
+	// generation is the registry snapshot generation the entries belong to;
+	// loads for older snapshots are not inserted
+	generation uint64
+
+	hits, misses, loads, shared, evictions uint64
+}
+
+// cacheEntry is an element of skillCache.lru.
+type cacheEntry struct {
+	key    string
+	skill  *Skill
+	size   int64
+	pinned bool
+}
+
+func newSkillCache() *skillCache {
+	return &skillCache{
+		lru:        list.New(),
+		entries:    make(map[string]*list.Element),
+		maxEntries: DefaultCacheEntries,
+		maxBytes:   DefaultCacheBytes,
+	}
+}
+
+// get returns a cached skill and records a hit or miss.
+func (c *skillCache) get(key string) (*Skill, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	el, ok := c.entries[key]
+	if !ok {
+		c.misses++
+		return nil, false
+	}
+	c.hits++
+	c.lru.MoveToFront(el)
+	return el.Value.(*cacheEntry).skill, true
+}
+
+// add caches a skill loaded for the given snapshot generation, then evicts
+// entries beyond the limits. Skills loaded for other generations are dropped.
+func (c *skillCache) add(key string, s *Skill, generation uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.loads++
+	if generation != c.generation {
+		return
+	}
+
+	if el, ok := c.entries[key]; ok {
+		c.remove(el)
+	}
+	entry := &cacheEntry{key: key, skill: s, size: skillSize(s)}
+	c.entries[key] = c.lru.PushFront(entry)
+	c.bytes += entry.size
+	c.evict()
+}
+
+// recordShared counts a miss served by another caller's load.
+func (c *skillCache) recordShared() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.shared++
+}
+
+// sweep moves the cache to a new generation, dropping or pinning the
+// entries according to fn.
+func (c *skillCache) sweep(generation uint64, fn func(key string) (drop, pin bool)) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.generation = generation
+	for key, el := range c.entries {
+		drop, pin := fn(key)
+		if drop {
+			c.remove(el)
+			continue
+		}
+		el.Value.(*cacheEntry).pinned = pin
+	}
+	c.evict()
+}
+
+// evict removes least recently used unpinned entries until within limits.
+func (c *skillCache) evict() {
+	el := c.lru.Back()
+	for el != nil && c.overLimit() {
+		prev := el.Prev()
+		if !el.Value.(*cacheEntry).pinned {
+			c.remove(el)
+			c.evictions++
+		}
+		el = prev
+	}
+}
+
+func (c *skillCache) overLimit() bool {
+	return (c.maxEntries > 0 && c.lru.Len() > c.maxEntries) ||
+		(c.maxBytes > 0 && c.bytes > c.maxBytes)
+}
+
+func (c *skillCache) remove(el *list.Element) {
+	entry := c.lru.Remove(el).(*cacheEntry)
+	delete(c.entries, entry.key)
+	c.bytes -= entry.size
+}
+
+func (c *skillCache) stats() CacheStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return CacheStats{
+		Hits:        c.hits,
+		Misses:      c.misses,
+		Loads:       c.loads,
+		SharedLoads: c.shared,
+		Evictions:   c.evictions,
+		Entries:     c.lru.Len(),
+		Bytes:       c.bytes,
+		MaxEntries:  c.maxEntries,
+		MaxBytes:    c.maxBytes,
+	}
+}
+
+// skillSize approximates the memory held by a cached skill.
+func skillSize(s *Skill) int64 {
+	size := int64(len(s.Name) + len(s.Description) + len(s.Version) + len(s.Path) + len(s.Content) + len(s.Extends) + len(s.ReplacedBy))
+	for _, f := range s.Files {
+		size += int64(len(f.RelPath) + len(f.AbsPath))
+	}
+	for _, t := range s.Tags {
+		size += int64(len(t))
+	}
+	for _, t := range s.Triggers {
+		size += int64(len(t))
+	}
+	for _, d := range s.DependsOn {
+		size += int64(len(d))
+	}
+	return size
+}
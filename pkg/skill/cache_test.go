@@ -0,0 +1,103 @@
+package skill
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"sync"
+	"testing"
+)
+
+func TestRegistryCache(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	for _, name := range []string{"alpha", "beta", "gamma"} {
+		writeTestSkill(t, filepath.Join(dir, name), name, "Skill "+name, "")
+	}
+	newRegistry := func(opts ...RegistryOption) *Registry {
+		r := NewRegistry(NewLoader(WithGlobalSkillsDir(filepath.Join(dir, "missing")), WithProjectSkillsDir(dir)), opts...)
+		if err := r.Initialize(ctx); err != nil {
+			t.Fatalf("Initialize() error: %v", err)
+		}
+		return r
+	}
+
+	// A budget that fits one skill but not two
+	alpha, err := NewLoader().LoadSkillFromDir(ctx, filepath.Join(dir, "alpha"), SourceProject)
+	if err != nil {
+		t.Fatal(err)
+	}
+	budget := skillSize(alpha) * 3 / 2
+
+	tests := []struct {
+		name     string
+		opts     []RegistryOption
+		gets     []string
+		expected CacheStats
+	}{
+		{
+			name:     "hits and misses",
+			gets:     []string{"alpha", "alpha", "beta", "alpha"},
+			expected: CacheStats{Hits: 2, Misses: 2, Loads: 2, Entries: 2},
+		},
+		{
+			name:     "entry limit evicts least recently used",
+			opts:     []RegistryOption{WithCacheSize(2)},
+			gets:     []string{"alpha", "beta", "alpha", "gamma", "beta"},
+			expected: CacheStats{Hits: 1, Misses: 4, Loads: 4, Evictions: 2, Entries: 2},
+		},
+		{
+			name:     "byte budget keeps one skill",
+			opts:     []RegistryOption{WithCacheSize(0), WithCacheBytes(budget)},
+			gets:     []string{"alpha", "beta", "beta", "alpha"},
+			expected: CacheStats{Hits: 1, Misses: 3, Loads: 3, Evictions: 2, Entries: 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newRegistry(tt.opts...)
+			for _, name := range tt.gets {
+				if _, err := r.Get(ctx, name); err != nil {
+					t.Fatalf("Get(%q) error: %v", name, err)
+				}
+			}
+
+			got := r.Stats()
+			if got.Hits != tt.expected.Hits || got.Misses != tt.expected.Misses || got.Loads != tt.expected.Loads ||
+				got.Evictions != tt.expected.Evictions || got.Entries != tt.expected.Entries {
+				t.Errorf("Stats() = %+v, want %+v", got, tt.expected)
+			}
+			if max := r.Stats().MaxBytes; max > 0 && got.Bytes > max {
+				t.Errorf("Bytes = %d exceeds budget %d", got.Bytes, max)
+			}
+		})
+	}
+
+	t.Run("concurrent gets load once", func(t *testing.T) {
+		r := newRegistry()
+		const callers = 32
+
+		var wg sync.WaitGroup
+		errs := make(chan error, callers)
+		for i := 0; i < callers; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				if _, err := r.Get(ctx, "gamma"); err != nil {
+					errs <- fmt.Errorf("Get() error: %w", err)
+				}
+			}()
+		}
+		wg.Wait()
+		close(errs)
+		for err := range errs {
+			t.Fatal(err)
+		}
+
+		got := r.Stats()
+		if got.Loads != 1 || got.Hits+got.Misses != callers || got.Misses != got.SharedLoads+1 {
+			t.Errorf("Stats() = %+v, want one load shared by %d callers", got, callers)
+		}
+	})
+}
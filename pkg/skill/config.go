@@ -0,0 +1,223 @@
+package skill
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ConfigFileName is the skills configuration file, looked up next to the
+// project skills directory (.eino/skills.yaml by default).
+const ConfigFileName = "skills.yaml"
+
+// Config selects which installed skills are enabled and adjusts how they are
+// presented. It is read from ConfigFileName:
+//
+//	disabled: [noisy-*]
+//	enabled-tags: [go, review]
+//	sources:
+//	  global:
+//	    disabled: [experimental-*]
+//	overrides:
+//	  deploy:
+//	    description: Deploy this repository with make release
+type Config struct {
+	// Rules apply to skills from every source
+	Rules `yaml:",inline"`
+
+	// Sources holds additional rules for the skills of one source
+	Sources map[SkillSource]Rules `yaml:"sources,omitempty"`
+
+	// Overrides replaces skill properties, keyed by skill name
+	Overrides map[string]Override `yaml:"overrides,omitempty"`
+}
+
+// Rules are allow and deny lists. Names are matched as path.Match globs
+// against skill names and tags against skill tags. A skill is disabled when
+// it matches a disabled name or tag; when an enabled list is set, skills must
+// match it as well. An exact name in a list takes precedence over globs and
+// tags, and source rules take precedence over the top-level ones: the
+// top-level rules only apply when no source rule matches and the source has
+// no enabled list.
+type Rules struct {
+	Enabled      []string `yaml:"enabled,omitempty"`
+	Disabled     []string `yaml:"disabled,omitempty"`
+	EnabledTags  []string `yaml:"enabled-tags,omitempty"`
+	DisabledTags []string `yaml:"disabled-tags,omitempty"`
+}
+
+// Override replaces properties of a skill.
+type Override struct {
+	// Description replaces the description shown in the system prompt
+	Description string `yaml:"description,omitempty"`
+}
+
+// errSkillDisabled marks a valid skill left out by the config. It wraps
+// fs.ErrNotExist so callers treat the skill as not installed.
+var errSkillDisabled = fmt.Errorf("skill disabled by %s: %w", ConfigFileName, fs.ErrNotExist)
+
+// LoadConfig reads a skills config file. A missing file yields an empty
+// config that enables every skill.
+func LoadConfig(file string) (*Config, error) {
+	data, err := os.ReadFile(file)
+	if errors.Is(err, fs.ErrNotExist) {
+		return &Config{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var cfg Config
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", file, err)
+	}
+	if err := cfg.validate(); err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", file, err)
+	}
+	return &cfg, nil
+}
+
+// Save writes the config to file, creating its directory if needed.
+func (c *Config) Save(file string) error {
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(c); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(file), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(file, buf.Bytes(), 0644)
+}
+
+// Allows reports whether the config enables a skill. A nil config enables
+// every skill.
+func (c *Config) Allows(m SkillMetadata) bool {
+	if c == nil {
+		return true
+	}
+	levels := c.levels(m.Source)
+
+	// Exact names are the most specific rules
+	for i := len(levels) - 1; i >= 0; i-- {
+		if slices.Contains(levels[i].Disabled, m.Name) {
+			return false
+		}
+		if slices.Contains(levels[i].Enabled, m.Name) {
+			return true
+		}
+	}
+
+	// Then globs and tags, from the most specific level to the top level;
+	// the first level with a matching rule or an enabled list decides
+	for i := len(levels) - 1; i >= 0; i-- {
+		r := levels[i]
+		if matchAny(r.Disabled, m.Name) || matchAnyTag(r.DisabledTags, m.Tags) {
+			return false
+		}
+		if matchAny(r.Enabled, m.Name) || matchAnyTag(r.EnabledTags, m.Tags) {
+			return true
+		}
+		if len(r.Enabled) > 0 || len(r.EnabledTags) > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Enable makes the config allow a skill: its name is removed from every
+// disabled list and, if other rules still exclude it, added to the
+// top-level enabled list.
+func (c *Config) Enable(m SkillMetadata) {
+	c.Disabled = remove(c.Disabled, m.Name)
+	for source, r := range c.Sources {
+		r.Disabled = remove(r.Disabled, m.Name)
+		c.Sources[source] = r
+	}
+	if !c.Allows(m) {
+		c.Enabled = append(c.Enabled, m.Name)
+	}
+}
+
+// Disable makes the config exclude a skill: its name is removed from every
+// enabled list and, if other rules still allow it, added to the top-level
+// disabled list.
+func (c *Config) Disable(m SkillMetadata) {
+	c.Enabled = remove(c.Enabled, m.Name)
+	for source, r := range c.Sources {
+		r.Enabled = remove(r.Enabled, m.Name)
+		c.Sources[source] = r
+	}
+	if c.Allows(m) {
+		c.Disabled = append(c.Disabled, m.Name)
+	}
+}
+
+// apply returns m with the config's overrides applied.
+func (c *Config) apply(m SkillMetadata) SkillMetadata {
+	if c == nil {
+		return m
+	}
+	if o, ok := c.Overrides[m.Name]; ok && o.Description != "" {
+		m.Description = o.Description
+	}
+	return m
+}
+
+// levels returns the rules that apply to a source, top-level rules first.
+func (c *Config) levels(source SkillSource) []Rules {
+	levels := []Rules{c.Rules}
+	if r, ok := c.Sources[source]; ok {
+		levels = append(levels, r)
+	}
+	return levels
+}
+
+// validate checks that every name pattern is a valid glob.
+func (c *Config) validate() error {
+	levels := []Rules{c.Rules}
+	for _, r := range c.Sources {
+		levels = append(levels, r)
+	}
+	for _, r := range levels {
+		for _, pattern := range slices.Concat(r.Enabled, r.Disabled, r.EnabledTags, r.DisabledTags) {
+			if _, err := path.Match(pattern, ""); err != nil {
+				return fmt.Errorf("bad pattern %q: %w", pattern, err)
+			}
+		}
+	}
+	return nil
+}
+
+// matchAny reports whether name matches one of the glob patterns.
+func matchAny(patterns []string, name string) bool {
+	for _, pattern := range patterns {
+		if ok, _ := path.Match(pattern, name); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// matchAnyTag reports whether one of the tags matches one of the patterns.
+func matchAnyTag(patterns, tags []string) bool {
+	for _, tag := range tags {
+		if matchAny(patterns, tag) {
+			return true
+		}
+	}
+	return false
+}
+
+// remove returns list without any occurrence of s.
+func remove(list []string, s string) []string {
+	return slices.DeleteFunc(list, func(v string) bool { return v == s })
+}
@@ -0,0 +1,194 @@
+package skill
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestConfigAllows(t *testing.T) {
+	config := &Config{
+		Rules: Rules{
+			Disabled:     []string{"noisy-*", "legacy"},
+			DisabledTags: []string{"experimental"},
+		},
+		Sources: map[SkillSource]Rules{
+			SourceGlobal: {Enabled: []string{"git-*", "noisy-but-useful"}, EnabledTags: []string{"review"}},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		config   *Config // nil uses the config above
+		metadata SkillMetadata
+		expected bool
+	}{
+		{name: "no rule matches", metadata: SkillMetadata{Name: "deploy", Source: SourceProject}, expected: true},
+		{name: "disabled glob", metadata: SkillMetadata{Name: "noisy-logs", Source: SourceProject}, expected: false},
+		{name: "disabled name", metadata: SkillMetadata{Name: "legacy", Source: SourceProject}, expected: false},
+		{name: "disabled tag", metadata: SkillMetadata{Name: "deploy", Tags: []string{"experimental"}, Source: SourceProject}, expected: false},
+		{name: "source allowlist by name", metadata: SkillMetadata{Name: "git-commit", Source: SourceGlobal}, expected: true},
+		{name: "source allowlist by tag", metadata: SkillMetadata{Name: "pr-review", Tags: []string{"review"}, Source: SourceGlobal}, expected: true},
+		{name: "outside source allowlist", metadata: SkillMetadata{Name: "deploy", Source: SourceGlobal}, expected: false},
+		{name: "exact name beats glob", metadata: SkillMetadata{Name: "noisy-but-useful", Source: SourceGlobal}, expected: true},
+		{
+			name:     "source enabled glob undoes top-level disabled glob",
+			config:   &Config{Rules: Rules{Disabled: []string{"noisy-*"}}, Sources: map[SkillSource]Rules{SourceGlobal: {Enabled: []string{"noisy-*"}}}},
+			metadata: SkillMetadata{Name: "noisy-logs", Source: SourceGlobal},
+			expected: true,
+		},
+		{
+			name:     "source disabled glob beats top-level enabled glob",
+			config:   &Config{Rules: Rules{Enabled: []string{"git-*"}}, Sources: map[SkillSource]Rules{SourceGlobal: {Disabled: []string{"git-legacy-*"}}}},
+			metadata: SkillMetadata{Name: "git-legacy-commit", Source: SourceGlobal},
+			expected: false,
+		},
+		{
+			name:     "source enabled list widens top-level one",
+			config:   &Config{Rules: Rules{Enabled: []string{"git-*"}}, Sources: map[SkillSource]Rules{SourceGlobal: {Enabled: []string{"pr-*"}}}},
+			metadata: SkillMetadata{Name: "pr-review", Source: SourceGlobal},
+			expected: true,
+		},
+		{
+			name:     "top-level enabled list applies to other sources",
+			config:   &Config{Rules: Rules{Enabled: []string{"git-*"}}, Sources: map[SkillSource]Rules{SourceGlobal: {Enabled: []string{"pr-*"}}}},
+			metadata: SkillMetadata{Name: "pr-review", Source: SourceProject},
+			expected: false,
+		},
+		{name: "nil config", metadata: SkillMetadata{Name: "legacy"}, expected: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := config
+			if tt.config != nil {
+				c = tt.config
+			}
+			if tt.name == "nil config" {
+				c = nil
+			}
+			if got := c.Allows(tt.metadata); got != tt.expected {
+				t.Errorf("Allows(%s) = %v, want %v", tt.metadata.Name, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestConfigEnableDisable(t *testing.T) {
+	tests := []struct {
+		name     string
+		config   Config
+		enable   bool
+		expected Config
+	}{
+		{
+			name:     "disable adds name",
+			config:   Config{},
+			expected: Config{Rules: Rules{Disabled: []string{"deploy"}}},
+		},
+		{
+			name:     "disable removes from allowlist only",
+			config:   Config{Rules: Rules{Enabled: []string{"deploy", "lint"}}},
+			expected: Config{Rules: Rules{Enabled: []string{"lint"}}},
+		},
+		{
+			name:     "enable removes name",
+			config:   Config{Rules: Rules{Disabled: []string{"deploy", "lint"}}},
+			enable:   true,
+			expected: Config{Rules: Rules{Disabled: []string{"lint"}}},
+		},
+		{
+			name:     "enable overrides glob",
+			config:   Config{Rules: Rules{Disabled: []string{"*"}}},
+			enable:   true,
+			expected: Config{Rules: Rules{Enabled: []string{"deploy"}, Disabled: []string{"*"}}},
+		},
+	}
+
+	m := SkillMetadata{Name: "deploy", Source: SourceProject}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := tt.config
+			if tt.enable {
+				config.Enable(m)
+			} else {
+				config.Disable(m)
+			}
+			if !slices.Equal(config.Enabled, tt.expected.Enabled) || !slices.Equal(config.Disabled, tt.expected.Disabled) {
+				t.Errorf("got enabled %v disabled %v, want enabled %v disabled %v",
+					config.Enabled, config.Disabled, tt.expected.Enabled, tt.expected.Disabled)
+			}
+			if got := config.Allows(m); got != tt.enable {
+				t.Errorf("Allows() = %v after change, want %v", got, tt.enable)
+			}
+		})
+	}
+}
+
+func TestRegistryConfig(t *testing.T) {
+	ctx := context.Background()
+	root := t.TempDir()
+	projectDir := filepath.Join(root, "skills")
+	configFile := filepath.Join(root, ConfigFileName)
+	writeTestSkill(t, filepath.Join(projectDir, "deploy"), "deploy", "Deploy services", "")
+	writeTestSkill(t, filepath.Join(projectDir, "noisy"), "noisy", "Noisy skill", "")
+
+	config := &Config{
+		Rules:     Rules{Disabled: []string{"noisy"}},
+		Overrides: map[string]Override{"deploy": {Description: "Deploy with make release"}},
+	}
+	if err := config.Save(configFile); err != nil {
+		t.Fatal(err)
+	}
+
+	loader := NewLoader(WithGlobalSkillsDir(filepath.Join(root, "missing")), WithProjectSkillsDir(projectDir))
+	if loader.ConfigFile() != configFile {
+		t.Fatalf("ConfigFile() = %q, want %q", loader.ConfigFile(), configFile)
+	}
+	registry := NewRegistry(loader)
+	if err := registry.Initialize(ctx); err != nil {
+		t.Fatalf("Initialize() error: %v", err)
+	}
+
+	if names := registry.Names(); !slices.Equal(names, []string{"deploy"}) {
+		t.Errorf("Names() = %v, want [deploy]", names)
+	}
+	if m, _ := registry.Resolve("deploy"); m.Description != "Deploy with make release" {
+		t.Errorf("description = %q, want override", m.Description)
+	}
+	if s, err := registry.Get(ctx, "deploy"); err != nil || s.Description != "Deploy with make release" {
+		t.Errorf("Get(deploy) = %+v, %v; want the overridden description", s, err)
+	}
+	if _, err := registry.Get(ctx, "noisy"); err == nil {
+		t.Error("Get(noisy) loaded a disabled skill")
+	}
+
+	// Disabling a loaded skill removes it on reload instead of keeping it stale
+	config.Disable(SkillMetadata{Name: "deploy"})
+	config.Enable(SkillMetadata{Name: "noisy"})
+	if err := config.Save(configFile); err != nil {
+		t.Fatal(err)
+	}
+	if err := registry.Reload(ctx); err != nil {
+		t.Fatalf("Reload() error: %v", err)
+	}
+	if names := registry.Names(); !slices.Equal(names, []string{"noisy"}) {
+		t.Errorf("Names() after reload = %v, want [noisy]", names)
+	}
+	if report := registry.LoadReport(); len(report.Stale) != 0 || len(report.Failures) != 0 {
+		t.Errorf("LoadReport() = %+v, want no stale skills or failures", report)
+	}
+
+	// A broken config fails the reload and keeps the current skills
+	if err := os.WriteFile(configFile, []byte("disabled: ["), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := registry.Reload(ctx); err == nil {
+		t.Error("Reload() with invalid config succeeded")
+	}
+	if names := registry.Names(); !slices.Equal(names, []string{"noisy"}) {
+		t.Errorf("Names() after failed reload = %v, want [noisy]", names)
+	}
+}
@@ -0,0 +1,237 @@
+package skill
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// ContentHit is a search result inside a skill body or reference file.
+type ContentHit struct {
+	// Skill is the name of the skill containing the hit
+	Skill string `json:"skill"`
+
+	// File is the path relative to the skill directory (SKILL.md or references/...)
+	File string `json:"file"`
+
+	// AbsPath is the absolute path of File
+	AbsPath string `json:"abs_path"`
+
+	// Heading is the section heading the hit is in ("" before the first heading)
+	Heading string `json:"heading,omitempty"`
+
+	// Line is the 1-based line of the snippet in File; for SKILL.md it counts
+	// from the start of the body, after the frontmatter
+	Line int `json:"line,omitempty"`
+
+	// Snippet is a short excerpt around the first matching line
+	Snippet string `json:"snippet"`
+
+	// Score is the BM25 relevance score
+	Score float64 `json:"score"`
+}
+
+// Content search limits.
+const (
+	// maxSearchFileSize skips reference files larger than this
+	maxSearchFileSize = 512 * 1024
+
+	// snippetLength is the maximum snippet length in bytes
+	snippetLength = 200
+
+	// weightHeading boosts terms in section headings
+	weightHeading = 2
+)
+
+// searchableExtensions are the reference file types indexed for full-text search.
+var searchableExtensions = map[string]bool{
+	".md": true, ".markdown": true, ".txt": true, ".rst": true,
+	".json": true, ".yaml": true, ".yml": true, ".toml": true, ".csv": true,
+}
+
+// contentSection is one indexed section of a skill body or reference file.
+type contentSection struct {
+	skill   string
+	file    string
+	absPath string
+	heading string
+	line    int
+	lines   []string
+}
+
+// contentIndex is a BM25 index over sections of skill bodies and references.
+type contentIndex struct {
+	sections []contentSection
+	bm25     *bm25Index
+}
+
+// SearchContent searches SKILL.md bodies and text reference files of all
+// registered skills and returns up to k ranked section hits (all for k <= 0).
+// The index is built on first use and discarded on reload.
+func (r *Registry) SearchContent(ctx context.Context, query string, k int) ([]ContentHit, error) {
+	return r.Snapshot().SearchContent(ctx, query, k)
+}
+
+// SearchContent searches the bodies and text reference files of the
+// snapshot's skills; see Registry.SearchContent.
+func (s *Snapshot) SearchContent(ctx context.Context, query string, k int) ([]ContentHit, error) {
+	idx, err := s.contentIndex(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	queryTerms := make(map[string]bool)
+	for _, term := range tokenize(query) {
+		queryTerms[term] = true
+	}
+
+	var hits []ContentHit
+	for _, h := range idx.bm25.search(query, 1) {
+		section := idx.sections[h.doc]
+		offset, snippet := makeSnippet(section.lines, queryTerms)
+		hits = append(hits, ContentHit{
+			Skill:   section.skill,
+			File:    section.file,
+			AbsPath: section.absPath,
+			Heading: section.heading,
+			Line:    section.line + offset,
+			Snippet: snippet,
+			Score:   h.score,
+		})
+	}
+
+	sort.SliceStable(hits, func(i, j int) bool {
+		return hits[i].Score > hits[j].Score
+	})
+
+	if k > 0 && len(hits) > k {
+		hits = hits[:k]
+	}
+	return hits, nil
+}
+
+// contentIndex returns the cached content index, building it if needed.
+func (s *Snapshot) contentIndex(ctx context.Context) (*contentIndex, error) {
+	s.contentMu.Lock()
+	idx := s.content
+	s.contentMu.Unlock()
+
+	if idx != nil {
+		return idx, nil
+	}
+
+	var sections []contentSection
+	for _, m := range s.metadata {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		default:
+		}
+
+		// Indexing is not a use of the skill, so it bypasses Get
+		skill, err := s.get(ctx, m.Ref())
+		if err == nil {
+			skill, err = s.inherit(ctx, skill, nil)
+		}
+		if err != nil {
+			continue // Skip skills that fail to load
+		}
+
+		sections = append(sections, splitContentSections(skill.Name, SkillFileName, skill.SkillMDPath(), skill.Content)...)
+
+		for _, f := range skill.Files {
+			if f.Type != FileTypeReference || !searchableExtensions[strings.ToLower(filepath.Ext(f.RelPath))] {
+				continue
+			}
+			info, err := os.Stat(f.AbsPath)
+			if err != nil || info.Size() > maxSearchFileSize {
+				continue
+			}
+			data, err := os.ReadFile(f.AbsPath)
+			if err != nil {
+				continue
+			}
+			sections = append(sections, splitContentSections(skill.Name, filepath.ToSlash(f.RelPath), f.AbsPath, string(data))...)
+		}
+	}
+
+	docs := make([][]indexField, len(sections))
+	for i, sec := range sections {
+		docs[i] = []indexField{
+			{sec.heading, weightHeading},
+			{strings.Join(sec.lines, "\n"), 1},
+		}
+		// The skill name is indexed once, on the first section of its
+		// SKILL.md, so name matches do not outrank every body match
+		if i == 0 || sections[i-1].skill != sec.skill {
+			docs[i] = append(docs[i], indexField{sec.skill, weightHeading})
+		}
+	}
+	idx = &contentIndex{sections: sections, bm25: newBM25Index(docs)}
+
+	s.contentMu.Lock()
+	if s.content == nil {
+		s.content = idx
+	}
+	idx = s.content
+	s.contentMu.Unlock()
+
+	return idx, nil
+}
+
+// splitContentSections splits a markdown document at every heading.
+func splitContentSections(skill, file, absPath, body string) []contentSection {
+	lines := strings.Split(body, "\n")
+	headings := scanHeadings(lines)
+
+	var sections []contentSection
+	add := func(heading string, start, end int) {
+		if start >= end {
+			return
+		}
+		sections = append(sections, contentSection{
+			skill:   skill,
+			file:    file,
+			absPath: absPath,
+			heading: heading,
+			line:    start + 1,
+			lines:   lines[start:end],
+		})
+	}
+
+	prev, heading := 0, ""
+	for _, h := range headings {
+		add(heading, prev, h.line)
+		prev, heading = h.line, h.text
+	}
+	add(heading, prev, len(lines))
+
+	return sections
+}
+
+// makeSnippet returns the offset and text of the first body line of a
+// section that contains a query term, falling back to the first non-empty
+// body line, then to the heading itself.
+func makeSnippet(lines []string, queryTerms map[string]bool) (int, string) {
+	fallback, fallbackLine := "", 0
+	if len(lines) > 0 {
+		fallback = strings.TrimSpace(lines[0])
+	}
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || (i == 0 && strings.HasPrefix(trimmed, "#")) {
+			continue
+		}
+		if fallbackLine == 0 {
+			fallback, fallbackLine = trimmed, i
+		}
+		for _, term := range tokenize(trimmed) {
+			if queryTerms[term] {
+				return i, truncate(trimmed, snippetLength)
+			}
+		}
+	}
+	return fallbackLine, truncate(fallback, snippetLength)
+}
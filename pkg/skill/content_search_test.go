@@ -0,0 +1,62 @@
+package skill
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestSearchContent(t *testing.T) {
+	dir := t.TempDir()
+	writeTestSkill(t, filepath.Join(dir, "deployer"), "deployer", "Deploy services", "")
+	writeTestSkill(t, filepath.Join(dir, "git-commit"), "git-commit", "Write commit messages", "")
+
+	refDir := filepath.Join(dir, "deployer", "references")
+	if err := os.MkdirAll(refDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	ref := "# Runbook\n\n## Rollback\n\nIf the canary fails, run the rollback playbook.\n\n## Release\n\nTag the git commit that was deployed.\n"
+	if err := os.WriteFile(filepath.Join(refDir, "runbook.md"), []byte(ref), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	loader := NewLoader(WithGlobalSkillsDir(filepath.Join(dir, "missing")), WithProjectSkillsDir(dir))
+	registry := NewRegistry(loader)
+	if err := registry.Initialize(context.Background()); err != nil {
+		t.Fatalf("Initialize() error: %v", err)
+	}
+
+	hits, err := registry.SearchContent(context.Background(), "canary rollback", 3)
+	if err != nil {
+		t.Fatalf("SearchContent() error: %v", err)
+	}
+	if len(hits) == 0 {
+		t.Fatal("SearchContent() returned no hits")
+	}
+
+	top := hits[0]
+	if top.Skill != "deployer" || top.File != "references/runbook.md" || top.Heading != "Rollback" {
+		t.Errorf("top hit = %+v, want deployer references/runbook.md › Rollback", top)
+	}
+	if top.Snippet != "If the canary fails, run the rollback playbook." {
+		t.Errorf("snippet = %q", top.Snippet)
+	}
+
+	// A skill name match counts once, not for every section of the skill
+	hits, err = registry.SearchContent(context.Background(), "git commit", 0)
+	if err != nil {
+		t.Fatalf("SearchContent() error: %v", err)
+	}
+	if len(hits) != 2 || hits[0].Skill != "git-commit" || hits[1].Heading != "Release" {
+		t.Errorf("SearchContent(git commit) = %+v, want the git-commit title and the Release section", hits)
+	}
+
+	hits, err = registry.SearchContent(context.Background(), "thing", 0)
+	if err != nil {
+		t.Fatalf("SearchContent() error: %v", err)
+	}
+	if len(hits) != 2 || hits[0].Heading != "Instructions" {
+		t.Errorf("SearchContent(thing) = %+v, want 2 Instructions hits", hits)
+	}
+}
@@ -0,0 +1,77 @@
+package skill
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// DeprecatedReference is a mention of a deprecated skill in another skill.
+type DeprecatedReference struct {
+	// Line is the 1-based line of the mention
+	Line int
+
+	// Skill is the deprecated skill
+	Skill SkillMetadata
+}
+
+// FindDeprecatedReferences returns the mentions of deprecated skills in the
+// text of a skill, typically its SKILL.md including the frontmatter, so
+// depends-on and extends entries are found too. Names match as whole words
+// in prose and frontmatter. Inside fenced code blocks only view_skill name
+// arguments count, so shell commands and sample output sharing a skill's
+// name are not flagged. Skills in deprecated that are not deprecated are
+// ignored. The skill itself must not be in deprecated.
+func FindDeprecatedReferences(text string, deprecated []SkillMetadata) []DeprecatedReference {
+	lines := strings.Split(text, "\n")
+	code := codeLines(lines)
+
+	var refs []DeprecatedReference
+	for _, m := range deprecated {
+		if !m.Deprecated {
+			continue
+		}
+		name := regexp.QuoteMeta(m.Name)
+		word := regexp.MustCompile(`(^|[^A-Za-z0-9_-])` + name + `($|[^A-Za-z0-9_-])`)
+		argument := regexp.MustCompile(`view_skill.*\bname\s*[=:]\s*[` + "`" + `"']?` + name + `($|[^A-Za-z0-9_-])`)
+		for i, line := range lines {
+			pattern := word
+			if code[i] {
+				pattern = argument
+			}
+			if pattern.MatchString(line) {
+				refs = append(refs, DeprecatedReference{Line: i + 1, Skill: m})
+			}
+		}
+	}
+	return refs
+}
+
+// codeLines reports which lines are inside fenced code blocks, including
+// the fences themselves.
+func codeLines(lines []string) []bool {
+	code := make([]bool, len(lines))
+	var fence string
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if marker := fenceMarker(trimmed); marker != "" {
+			code[i] = true
+			if fence == "" {
+				fence = marker
+			} else if strings.HasPrefix(trimmed, fence) {
+				fence = ""
+			}
+			continue
+		}
+		code[i] = fence != ""
+	}
+	return code
+}
+
+// String describes the reference and the replacement to use instead.
+func (r DeprecatedReference) String() string {
+	if r.Skill.ReplacedBy != "" {
+		return fmt.Sprintf("references deprecated skill '%s'; use '%s' instead", r.Skill.Name, r.Skill.ReplacedBy)
+	}
+	return fmt.Sprintf("references deprecated skill '%s'", r.Skill.Name)
+}
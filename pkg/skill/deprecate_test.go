@@ -0,0 +1,113 @@
+package skill
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestFindDeprecatedReferences(t *testing.T) {
+	deprecated := []SkillMetadata{
+		{Name: "old-commit", Deprecated: true, ReplacedBy: "git-commit"},
+		{Name: "legacy", Deprecated: true},
+		{Name: "current"},
+	}
+
+	tests := []struct {
+		name  string
+		text  string
+		lines []int
+	}{
+		{name: "frontmatter dependency", text: "---\nname: release\ndepends-on: [old-commit]\n---", lines: []int{3}},
+		{name: "backticked mention", text: "# release\n\nFirst run the `legacy` skill.", lines: []int{3}},
+		{name: "frontmatter list item", text: "---\nname: release\ndepends-on:\n  - legacy\n---", lines: []int{4}},
+		{name: "prose mention", text: "# release\n\nRun the old-commit workflow first.", lines: []int{3}},
+		{name: "view_skill argument in code", text: "```\nview_skill(name=\"legacy\")\n```", lines: []int{2}},
+		{name: "other code is ignored", text: "```bash\nlegacy --help\ngit old-commit\n```\n\nDone.", lines: nil},
+		{name: "longer names do not match", text: "Use old-commit-v2 or my-legacy-tool.", lines: nil},
+		{name: "not deprecated", text: "Use current.", lines: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var lines []int
+			for _, ref := range FindDeprecatedReferences(tt.text, deprecated) {
+				lines = append(lines, ref.Line)
+			}
+			if !slices.Equal(lines, tt.lines) {
+				t.Errorf("lines = %v, want %v", lines, tt.lines)
+			}
+		})
+	}
+
+	if got := (DeprecatedReference{Skill: deprecated[0]}).String(); !strings.Contains(got, "use 'git-commit' instead") {
+		t.Errorf("String() = %q, want the replacement", got)
+	}
+}
+
+func TestDeprecatedSkills(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	write := func(name, frontmatter, body string) {
+		t.Helper()
+		if err := os.MkdirAll(filepath.Join(dir, name), 0755); err != nil {
+			t.Fatal(err)
+		}
+		content := "---\nname: " + name + "\ndescription: Skill " + name + "\n" + frontmatter + "---\n\n# " + name + "\n\n" + body + "\n"
+		if err := os.WriteFile(filepath.Join(dir, name, SkillFileName), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("old-commit", "deprecated: true\nreplaced-by: git-commit\n", "Old instructions.")
+	write("git-commit", "", "New instructions.")
+	write("legacy", "deprecated: true\n", "Legacy instructions.")
+	write("release", "depends-on: [old-commit]\n", "Run the legacy skill first.")
+
+	registry := NewRegistry(NewLoader(WithGlobalSkillsDir(filepath.Join(dir, "missing")), WithProjectSkillsDir(dir)))
+	if err := registry.Initialize(ctx); err != nil {
+		t.Fatalf("Initialize() error: %v", err)
+	}
+
+	m, err := registry.Resolve("old-commit")
+	if err != nil || !m.Deprecated || m.ReplacedBy != "git-commit" {
+		t.Fatalf("Resolve(old-commit) = %+v, %v", m, err)
+	}
+	if names := promptNames(registry.GenerateSystemPromptSection()); !slices.Equal(names, []string{"git-commit", "release"}) {
+		t.Errorf("prompt lists %v, want deprecated skills hidden", names)
+	}
+
+	all := NewRegistry(registry.loader, WithPromptOptions(PromptOptions{IncludeDeprecated: true}))
+	if err := all.Initialize(ctx); err != nil {
+		t.Fatalf("Initialize() error: %v", err)
+	}
+	if names := promptNames(all.GenerateSystemPromptSection()); len(names) != 4 {
+		t.Errorf("prompt lists %v, want all skills with IncludeDeprecated", names)
+	}
+
+	linter, err := NewLinter(nil)
+	if err != nil {
+		t.Fatalf("NewLinter() error: %v", err)
+	}
+	diagnostics, err := linter.Lint(ctx, []string{dir})
+	if err != nil {
+		t.Fatalf("Lint() error: %v", err)
+	}
+	var found []string
+	for _, d := range diagnostics {
+		if d.Rule == "deprecated-reference" || d.Rule == "deprecation" {
+			found = append(found, filepath.Base(d.Skill)+": "+d.Message)
+		}
+	}
+	want := []string{
+		"legacy: deprecated skill does not name a replacement (replaced-by)",
+		"release: references deprecated skill 'legacy'",
+		"release: references deprecated skill 'old-commit'; use 'git-commit' instead",
+	}
+	slices.Sort(found)
+	if !slices.Equal(found, want) {
+		t.Errorf("deprecation diagnostics = %v, want %v", found, want)
+	}
+}
@@ -0,0 +1,225 @@
+package skill
+
+import (
+	"fmt"
+	"log/slog"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Predefined dependency errors.
+var (
+	ErrMissingDependency = &SkillError{Message: "missing skill dependency"}
+	ErrDependencyCycle   = &SkillError{Message: "skill dependency cycle"}
+)
+
+// DependencyGraph is the graph of depends-on declarations between the
+// default versions of a snapshot's skills.
+type DependencyGraph struct {
+	// Skills are all skill names, in name order
+	Skills []string
+
+	// Edges maps a skill to the names of the skills it depends on, in
+	// declaration order; missing dependencies are left out
+	Edges map[string][]string
+
+	// Missing are the dependencies no installed version satisfies
+	Missing []MissingDependency
+
+	// Cycles are the dependency cycles, each a path that starts and ends
+	// with the same skill
+	Cycles [][]string
+}
+
+// MissingDependency is a depends-on reference that cannot be resolved.
+type MissingDependency struct {
+	// Skill is the name of the skill declaring the dependency
+	Skill string
+
+	// Ref is the unresolved reference, possibly with a version
+	Ref string
+}
+
+// Dependencies returns the dependency graph of the current snapshot.
+func (r *Registry) Dependencies() *DependencyGraph {
+	return r.Snapshot().Dependencies()
+}
+
+// Prerequisites returns the skills the referenced skill transitively
+// depends on, in the order they should be loaded.
+func (r *Registry) Prerequisites(ref string) ([]SkillMetadata, error) {
+	return r.Snapshot().Prerequisites(ref)
+}
+
+// Dependencies returns the dependency graph of the snapshot's skills. Skills
+// outside a filtered snapshot count as missing. The result is a copy and may
+// be modified by the caller.
+func (s *Snapshot) Dependencies() *DependencyGraph {
+	return s.dependencies().clone()
+}
+
+// dependencies returns the snapshot's shared graph, building it on first use.
+func (s *Snapshot) dependencies() *DependencyGraph {
+	s.depsOnce.Do(func() {
+		s.deps = s.buildDependencies()
+	})
+	return s.deps
+}
+
+// clone returns a copy of g that shares no slices or maps with it.
+func (g *DependencyGraph) clone() *DependencyGraph {
+	c := &DependencyGraph{
+		Skills:  slices.Clone(g.Skills),
+		Edges:   make(map[string][]string, len(g.Edges)),
+		Missing: slices.Clone(g.Missing),
+	}
+	for name, deps := range g.Edges {
+		c.Edges[name] = slices.Clone(deps)
+	}
+	for _, cycle := range g.Cycles {
+		c.Cycles = append(c.Cycles, slices.Clone(cycle))
+	}
+	return c
+}
+
+// buildDependencies resolves the depends-on references of every skill and
+// detects cycles.
+func (s *Snapshot) buildDependencies() *DependencyGraph {
+	g := &DependencyGraph{Skills: s.Names(), Edges: make(map[string][]string)}
+	for _, m := range s.metadata {
+		for _, ref := range m.DependsOn {
+			dep, err := s.Resolve(ref)
+			if err != nil {
+				g.Missing = append(g.Missing, MissingDependency{Skill: m.Name, Ref: ref})
+				continue
+			}
+			if !slices.Contains(g.Edges[m.Name], dep.Name) {
+				g.Edges[m.Name] = append(g.Edges[m.Name], dep.Name)
+			}
+		}
+	}
+
+	// Depth-first search in name order; a back edge closes a cycle
+	const (
+		visiting = 1
+		done     = 2
+	)
+	state := make(map[string]int, len(g.Skills))
+	var stack []string
+	var visit func(name string)
+	visit = func(name string) {
+		state[name] = visiting
+		stack = append(stack, name)
+		for _, dep := range g.Edges[name] {
+			switch state[dep] {
+			case 0:
+				visit(dep)
+			case visiting:
+				start := slices.Index(stack, dep)
+				g.Cycles = append(g.Cycles, append(slices.Clone(stack[start:]), dep))
+			}
+		}
+		stack = stack[:len(stack)-1]
+		state[name] = done
+	}
+	for _, name := range g.Skills {
+		if state[name] == 0 {
+			visit(name)
+		}
+	}
+	return g
+}
+
+// Prerequisites returns the skills the referenced skill transitively
+// depends on, dependencies first. It fails if a dependency is missing or
+// the dependencies form a cycle.
+func (s *Snapshot) Prerequisites(ref string) ([]SkillMetadata, error) {
+	root, err := s.Resolve(ref)
+	if err != nil {
+		return nil, err
+	}
+
+	var order []SkillMetadata
+	visiting := make(map[string]bool)
+	done := make(map[string]bool)
+	var visit func(m SkillMetadata, path []string) error
+	visit = func(m SkillMetadata, path []string) error {
+		key := m.Ref()
+		path = append(path, m.Name)
+		if visiting[key] {
+			return &SkillError{SkillPath: root.Ref(), Message: ErrDependencyCycle.Message + ": " + strings.Join(path, " -> ")}
+		}
+		if done[key] {
+			return nil
+		}
+
+		visiting[key] = true
+		for _, depRef := range m.DependsOn {
+			dep, err := s.Resolve(depRef)
+			if err != nil {
+				return &SkillError{SkillPath: m.Ref(), Message: fmt.Sprintf("%s %s", ErrMissingDependency.Message, depRef), Err: err}
+			}
+			if err := visit(dep, path); err != nil {
+				return err
+			}
+		}
+		visiting[key] = false
+		done[key] = true
+		order = append(order, m)
+		return nil
+	}
+
+	if err := visit(root, nil); err != nil {
+		return nil, err
+	}
+	// The skill itself comes last
+	return order[:len(order)-1], nil
+}
+
+// logDependencies warns about missing dependencies and cycles.
+func (s *Snapshot) logDependencies() {
+	g := s.dependencies()
+	for _, m := range g.Missing {
+		s.registry.logger.Warn(ErrMissingDependency.Message,
+			slog.String(logKeySkill, m.Skill),
+			slog.String("dependency", m.Ref))
+	}
+	for _, cycle := range g.Cycles {
+		s.registry.logger.Warn(ErrDependencyCycle.Message,
+			slog.String("cycle", strings.Join(cycle, " -> ")))
+	}
+}
+
+// DOT renders the graph in Graphviz DOT format. Missing dependencies are
+// drawn as dashed nodes and edges on a cycle are red.
+func (g *DependencyGraph) DOT() string {
+	onCycle := make(map[[2]string]bool)
+	for _, cycle := range g.Cycles {
+		for i := 0; i+1 < len(cycle); i++ {
+			onCycle[[2]string{cycle[i], cycle[i+1]}] = true
+		}
+	}
+
+	var sb strings.Builder
+	sb.WriteString("digraph skills {\n")
+	sb.WriteString("  node [shape=box];\n")
+	for _, name := range g.Skills {
+		sb.WriteString(fmt.Sprintf("  %s;\n", strconv.Quote(name)))
+	}
+	for _, name := range g.Skills {
+		for _, dep := range g.Edges[name] {
+			attrs := ""
+			if onCycle[[2]string{name, dep}] {
+				attrs = " [color=red]"
+			}
+			sb.WriteString(fmt.Sprintf("  %s -> %s%s;\n", strconv.Quote(name), strconv.Quote(dep), attrs))
+		}
+	}
+	for _, m := range g.Missing {
+		sb.WriteString(fmt.Sprintf("  %s [style=dashed, color=red];\n", strconv.Quote(m.Ref)))
+		sb.WriteString(fmt.Sprintf("  %s -> %s [style=dashed, color=red];\n", strconv.Quote(m.Skill), strconv.Quote(m.Ref)))
+	}
+	sb.WriteString("}\n")
+	return sb.String()
+}
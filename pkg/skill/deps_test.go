@@ -0,0 +1,106 @@
+package skill
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// writeDependentSkill writes a skill that depends on the given references.
+func writeDependentSkill(t *testing.T, dir, name string, deps ...string) {
+	t.Helper()
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	content := "---\nname: " + name + "\ndescription: Skill " + name + "\n"
+	if len(deps) > 0 {
+		content += "depends-on: [" + strings.Join(deps, ", ") + "]\n"
+	}
+	content += "---\n\n# " + name + "\n"
+	if err := os.WriteFile(filepath.Join(dir, SkillFileName), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestDependencies(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	writeDependentSkill(t, filepath.Join(dir, "release"), "release", "git-commit", "changelog")
+	writeDependentSkill(t, filepath.Join(dir, "changelog"), "changelog", "git-commit")
+	writeDependentSkill(t, filepath.Join(dir, "git-commit"), "git-commit")
+	writeDependentSkill(t, filepath.Join(dir, "deploy"), "deploy", "release", "secrets@^2")
+	writeDependentSkill(t, filepath.Join(dir, "ping"), "ping", "pong")
+	writeDependentSkill(t, filepath.Join(dir, "pong"), "pong", "ping")
+
+	registry := NewRegistry(NewLoader(WithGlobalSkillsDir(filepath.Join(dir, "missing")), WithProjectSkillsDir(dir)))
+	if err := registry.Initialize(ctx); err != nil {
+		t.Fatalf("Initialize() error: %v", err)
+	}
+
+	graph := registry.Dependencies()
+	if got := graph.Edges["release"]; !slices.Equal(got, []string{"git-commit", "changelog"}) {
+		t.Errorf("Edges[release] = %v, want [git-commit changelog]", got)
+	}
+	if want := []MissingDependency{{Skill: "deploy", Ref: "secrets@^2"}}; !slices.Equal(graph.Missing, want) {
+		t.Errorf("Missing = %v, want %v", graph.Missing, want)
+	}
+	if len(graph.Cycles) != 1 || !slices.Equal(graph.Cycles[0], []string{"ping", "pong", "ping"}) {
+		t.Errorf("Cycles = %v, want [[ping pong ping]]", graph.Cycles)
+	}
+	dot := graph.DOT()
+	for _, want := range []string{`"release" -> "changelog";`, `"ping" -> "pong" [color=red];`, `"secrets@^2" [style=dashed, color=red];`} {
+		if !strings.Contains(dot, want) {
+			t.Errorf("DOT() missing %q:\n%s", want, dot)
+		}
+	}
+
+	// The graph is a copy; changing it does not affect the registry
+	graph.Edges["release"][0] = "mutated"
+	graph.Cycles[0][0] = "mutated"
+	if again := registry.Dependencies(); again.Edges["release"][0] != "git-commit" || again.Cycles[0][0] != "ping" {
+		t.Errorf("Dependencies() after mutation = %+v", again)
+	}
+
+	tests := []struct {
+		name    string
+		ref     string
+		want    []string
+		wantErr string
+	}{
+		{name: "no dependencies", ref: "git-commit", want: []string{}},
+		{name: "transitive in load order", ref: "release", want: []string{"git-commit", "changelog"}},
+		{name: "missing dependency", ref: "deploy", wantErr: "missing skill dependency secrets@^2"},
+		{name: "cycle", ref: "ping", wantErr: "skill dependency cycle: ping -> pong -> ping"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prereqs, err := registry.Prerequisites(tt.ref)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Prerequisites() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Prerequisites() error: %v", err)
+			}
+			got := []string{}
+			for _, m := range prereqs {
+				got = append(got, m.Name)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("Prerequisites() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	// Dependencies outside a view are missing from it
+	view := registry.View(Filter{Names: []string{"release", "changelog"}})
+	if _, err := view.Prerequisites("release"); err == nil {
+		t.Error("Prerequisites() resolved a dependency outside the view")
+	}
+}
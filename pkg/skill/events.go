@@ -0,0 +1,155 @@
+package skill
+
+import (
+	"slices"
+	"sort"
+	"time"
+)
+
+// EventType identifies the kind of registry change.
+type EventType string
+
+const (
+	// EventSkillAdded is emitted when a skill name appears in the registry
+	EventSkillAdded EventType = "skill_added"
+
+	// EventSkillRemoved is emitted when a skill name disappears from the registry
+	EventSkillRemoved EventType = "skill_removed"
+
+	// EventSkillUpdated is emitted when the default version of a skill changes,
+	// its metadata (description, tags, triggers, path) is edited or its
+	// SKILL.md is otherwise modified
+	EventSkillUpdated EventType = "skill_updated"
+
+	// EventReloadFailed is emitted when loading skills from disk fails
+	EventReloadFailed EventType = "reload_failed"
+
+	// EventSkillInvalid is emitted when a skill directory stops parsing or
+	// its error changes. A previously valid skill stays registered as stale.
+	EventSkillInvalid EventType = "skill_invalid"
+)
+
+// Event describes a change to the registry.
+type Event struct {
+	// Type is the kind of change
+	Type EventType `json:"type"`
+
+	// Name is the affected skill name ("" for EventReloadFailed and for
+	// invalid skills that never loaded)
+	Name string `json:"name,omitempty"`
+
+	// OldVersion is the previous default version (updated and removed skills)
+	OldVersion string `json:"old_version,omitempty"`
+
+	// NewVersion is the new default version (added and updated skills)
+	NewVersion string `json:"new_version,omitempty"`
+
+	// Metadata is the new metadata, or the last known metadata for removed
+	// and invalid skills (only Path and Source for skills that never loaded)
+	Metadata SkillMetadata `json:"metadata"`
+
+	// Err is the error for EventReloadFailed and EventSkillInvalid
+	Err error `json:"-"`
+
+	// Time is when the change was observed
+	Time time.Time `json:"time"`
+}
+
+// EventHandler receives registry events.
+type EventHandler func(Event)
+
+// Subscribe registers a handler for registry events and returns a function
+// that removes it. Handlers run synchronously, in subscription order, on the
+// goroutine that changed the registry (usually the watcher), after the new
+// state is visible. They must return quickly and must not call Subscribe.
+func (r *Registry) Subscribe(handler EventHandler) (unsubscribe func()) {
+	r.subMu.Lock()
+	defer r.subMu.Unlock()
+
+	r.nextSubID++
+	id := r.nextSubID
+	r.subscribers = append(r.subscribers, subscriber{id: id, handler: handler})
+
+	return func() {
+		r.subMu.Lock()
+		defer r.subMu.Unlock()
+		r.subscribers = slices.DeleteFunc(r.subscribers, func(s subscriber) bool {
+			return s.id == id
+		})
+	}
+}
+
+// subscriber is a registered event handler.
+type subscriber struct {
+	id      int
+	handler EventHandler
+}
+
+// emit delivers events to all current subscribers.
+func (r *Registry) emit(events ...Event) {
+	if len(events) == 0 {
+		return
+	}
+
+	r.subMu.Lock()
+	handlers := make([]EventHandler, len(r.subscribers))
+	for i, s := range r.subscribers {
+		handlers[i] = s.handler
+	}
+	r.subMu.Unlock()
+
+	for _, e := range events {
+		r.logEvent(e)
+		for _, h := range handlers {
+			h(e)
+		}
+	}
+}
+
+// diffMetadata compares two sets of default-version metadata and returns
+// the resulting events, sorted by skill name.
+func diffMetadata(old, updated []SkillMetadata) []Event {
+	now := time.Now()
+	before := make(map[string]SkillMetadata, len(old))
+	for _, m := range old {
+		before[m.Name] = m
+	}
+
+	var events []Event
+	for _, m := range updated {
+		prev, ok := before[m.Name]
+		delete(before, m.Name)
+		switch {
+		case !ok:
+			events = append(events, Event{Type: EventSkillAdded, Name: m.Name, NewVersion: m.Version, Metadata: m, Time: now})
+		case !metadataEqual(prev, m):
+			events = append(events, Event{Type: EventSkillUpdated, Name: m.Name, OldVersion: prev.Version, NewVersion: m.Version, Metadata: m, Time: now})
+		}
+	}
+	for _, m := range before {
+		events = append(events, Event{Type: EventSkillRemoved, Name: m.Name, OldVersion: m.Version, Metadata: m, Time: now})
+	}
+
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].Name < events[j].Name
+	})
+	return events
+}
+
+// metadataEqual reports whether two metadata records are identical and
+// were loaded from the same revision of SKILL.md.
+func metadataEqual(a, b SkillMetadata) bool {
+	return a.Name == b.Name &&
+		a.modTime.Equal(b.modTime) &&
+		a.Description == b.Description &&
+		a.Version == b.Version &&
+		a.Source == b.Source &&
+		a.Path == b.Path &&
+		a.Stale == b.Stale &&
+		a.Extends == b.Extends &&
+		a.Deprecated == b.Deprecated &&
+		a.ReplacedBy == b.ReplacedBy &&
+		slices.Equal(a.Tags, b.Tags) &&
+		slices.Equal(a.Triggers, b.Triggers) &&
+		slices.Equal(a.DependsOn, b.DependsOn)
+}
@@ -0,0 +1,113 @@
+package skill
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestRegistrySubscribe(t *testing.T) {
+	dir := t.TempDir()
+	writeTestSkill(t, filepath.Join(dir, "deploy"), "deploy", "Deploy services", "1.0.0")
+	writeTestSkill(t, filepath.Join(dir, "lint"), "lint", "Lint code", "")
+
+	loader := NewLoader(WithGlobalSkillsDir(filepath.Join(dir, "missing")), WithProjectSkillsDir(dir))
+	registry := NewRegistry(loader)
+	if err := registry.Initialize(context.Background()); err != nil {
+		t.Fatalf("Initialize() error: %v", err)
+	}
+
+	var events []Event
+	unsubscribe := registry.Subscribe(func(e Event) {
+		events = append(events, e)
+	})
+
+	// Update deploy, remove lint, add review
+	writeTestSkill(t, filepath.Join(dir, "deploy"), "deploy", "Deploy services", "1.1.0")
+	if err := os.RemoveAll(filepath.Join(dir, "lint")); err != nil {
+		t.Fatal(err)
+	}
+	writeTestSkill(t, filepath.Join(dir, "review"), "review", "Review pull requests", "")
+
+	if err := registry.Reload(context.Background()); err != nil {
+		t.Fatalf("Reload() error: %v", err)
+	}
+
+	expected := []Event{
+		{Type: EventSkillUpdated, Name: "deploy", OldVersion: "1.0.0", NewVersion: "1.1.0"},
+		{Type: EventSkillRemoved, Name: "lint"},
+		{Type: EventSkillAdded, Name: "review"},
+	}
+	if len(events) != len(expected) {
+		t.Fatalf("got %d events, want %d: %+v", len(events), len(expected), events)
+	}
+	for i, e := range expected {
+		got := events[i]
+		if got.Type != e.Type || got.Name != e.Name || got.OldVersion != e.OldVersion || got.NewVersion != e.NewVersion {
+			t.Errorf("event %d = %s %s %q->%q, want %s %s %q->%q",
+				i, got.Type, got.Name, got.OldVersion, got.NewVersion, e.Type, e.Name, e.OldVersion, e.NewVersion)
+		}
+	}
+
+	// An unchanged reload emits nothing
+	events = nil
+	if err := registry.Reload(context.Background()); err != nil {
+		t.Fatalf("Reload() error: %v", err)
+	}
+	if len(events) != 0 {
+		t.Errorf("unchanged reload emitted %d events", len(events))
+	}
+
+	// Editing only the body updates the skill
+	skillMD := filepath.Join(dir, "deploy", SkillFileName)
+	content := "---\nname: deploy\ndescription: Deploy services\nversion: 1.1.0\n---\n\n# deploy\n\nDeploy with the new pipeline.\n"
+	if err := os.WriteFile(skillMD, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	later := time.Now().Add(time.Hour)
+	if err := os.Chtimes(skillMD, later, later); err != nil {
+		t.Fatal(err)
+	}
+	if err := registry.Reload(context.Background()); err != nil {
+		t.Fatalf("Reload() error: %v", err)
+	}
+	if len(events) != 1 || events[0].Type != EventSkillUpdated || events[0].Name != "deploy" || events[0].NewVersion != "1.1.0" {
+		t.Errorf("body edit emitted %+v, want one deploy update", events)
+	}
+	events = nil
+
+	unsubscribe()
+	writeTestSkill(t, filepath.Join(dir, "lint"), "lint", "Lint code", "")
+	if err := registry.Reload(context.Background()); err != nil {
+		t.Fatalf("Reload() error: %v", err)
+	}
+	if len(events) != 0 {
+		t.Errorf("unsubscribed handler received %d events", len(events))
+	}
+}
+
+func TestRegistrySubscribeReloadFailed(t *testing.T) {
+	dir := t.TempDir()
+	// A regular file where the skills directory should be fails to load
+	notDir := filepath.Join(dir, "skills")
+	if err := os.WriteFile(notDir, []byte("x"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	loader := NewLoader(WithGlobalSkillsDir(filepath.Join(dir, "missing")), WithProjectSkillsDir(notDir))
+	registry := NewRegistry(loader)
+
+	var events []Event
+	registry.Subscribe(func(e Event) {
+		events = append(events, e)
+	})
+
+	if err := registry.Reload(context.Background()); err == nil {
+		t.Fatal("Reload() expected error")
+	}
+	if len(events) != 1 || events[0].Type != EventReloadFailed || events[0].Err == nil {
+		t.Errorf("events = %+v, want one %s with error", events, EventReloadFailed)
+	}
+}
@@ -0,0 +1,459 @@
+package skill
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"time"
+	"unicode/utf8"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ExportFormat is the encoding of a registry export.
+type ExportFormat string
+
+const (
+	// ExportJSON writes the export as a single JSON document
+	ExportJSON ExportFormat = "json"
+
+	// ExportTar writes a tar archive with a manifest.json and one directory
+	// per skill, laid out as global/<ref>/ and project/<ref>/
+	ExportTar ExportFormat = "tar"
+)
+
+// ExportVersion is the version of the export format written by Export.
+const ExportVersion = 1
+
+// exportManifest is the name of the manifest in tar exports.
+const exportManifest = "manifest.json"
+
+// Export is a frozen copy of a registry snapshot: the metadata, SKILL.md
+// content and bundled text files of every installed skill version, as an
+// agent saw them. Binary files are not exported.
+type Export struct {
+	// Version is the export format version
+	Version int `json:"version"`
+
+	// Generation is the generation of the exported snapshot
+	Generation uint64 `json:"generation"`
+
+	// ExportedAt is when the export was written
+	ExportedAt time.Time `json:"exported_at"`
+
+	// Pins records the default version of skills whose default is not the
+	// newest installed version
+	Pins map[string]string `json:"pins,omitempty"`
+
+	// Skills are all exported skill versions, in name order and newest first
+	Skills []ExportedSkill `json:"skills"`
+}
+
+// ExportedSkill is a skill in an export. Path and Source refer to where
+// the skill was installed when it was exported.
+type ExportedSkill struct {
+	SkillMetadata
+
+	// AllowedTools, Author and License are the SKILL.md frontmatter fields
+	// that are not part of the metadata
+	AllowedTools []string `json:"allowed_tools,omitempty"`
+	Author       string   `json:"author,omitempty"`
+	License      string   `json:"license,omitempty"`
+
+	// Content is the SKILL.md body without frontmatter
+	Content string `json:"content"`
+
+	// Files are the bundled text files
+	Files []ExportedFile `json:"files,omitempty"`
+}
+
+// ExportedFile is a bundled text file in an export.
+type ExportedFile struct {
+	// Path is the slash-separated path relative to the skill directory
+	Path string `json:"path"`
+
+	// Type is the file category
+	Type SkillFileType `json:"type"`
+
+	// Executable is set for files with an executable mode
+	Executable bool `json:"executable,omitempty"`
+
+	// Content is the file content; empty in tar manifests
+	Content string `json:"content,omitempty"`
+}
+
+// Export writes every skill version of the current snapshot to w.
+func (r *Registry) Export(ctx context.Context, w io.Writer, format ExportFormat) error {
+	return r.Snapshot().Export(ctx, w, format)
+}
+
+// Export writes every skill version of the snapshot to w.
+func (s *Snapshot) Export(ctx context.Context, w io.Writer, format ExportFormat) error {
+	exp, err := s.export(ctx)
+	if err != nil {
+		return err
+	}
+	return exp.Write(w, format)
+}
+
+// export collects the snapshot's skills.
+func (s *Snapshot) export(ctx context.Context) (*Export, error) {
+	exp := &Export{
+		Version:    ExportVersion,
+		Generation: s.generation,
+		ExportedAt: time.Now().UTC(),
+		Skills:     []ExportedSkill{},
+	}
+
+	names := make([]string, 0, len(s.versions))
+	for name := range s.versions {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	for _, name := range names {
+		versions := s.versions[name]
+		if def := s.registry.defaultVersion(name, versions); def.Version != versions[0].Version {
+			if exp.Pins == nil {
+				exp.Pins = make(map[string]string)
+			}
+			exp.Pins[name] = def.Version
+		}
+
+		for _, m := range versions {
+			skill, err := s.get(ctx, m.Ref())
+			if err != nil {
+				return nil, fmt.Errorf("failed to load skill %s: %w", m.Ref(), err)
+			}
+			content, err := s.skillContent(ctx, skill)
+			if err != nil {
+				return nil, fmt.Errorf("failed to load skill %s: %w", m.Ref(), err)
+			}
+
+			exported := ExportedSkill{
+				SkillMetadata: cloneMetadata(m),
+				AllowedTools:  slices.Clone(skill.AllowedTools),
+				Author:        skill.Author,
+				License:       skill.License,
+				Content:       content,
+			}
+			for _, f := range skill.Files {
+				file, err := exportFile(f)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read %s: %w", f.AbsPath, err)
+				}
+				if file == nil {
+					s.registry.logger.Debug("skipped binary file in export",
+						slog.String(logKeySkill, m.Ref()),
+						slog.String(logKeyPath, f.AbsPath))
+					continue
+				}
+				exported.Files = append(exported.Files, *file)
+			}
+			exp.Skills = append(exp.Skills, exported)
+		}
+	}
+
+	return exp, nil
+}
+
+// exportFile reads a bundled file, returning nil for binary files.
+func exportFile(f SkillFile) (*ExportedFile, error) {
+	info, err := os.Stat(f.AbsPath)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(f.AbsPath)
+	if err != nil {
+		return nil, err
+	}
+	if !utf8.Valid(data) || bytes.IndexByte(data, 0) >= 0 {
+		return nil, nil
+	}
+
+	return &ExportedFile{
+		Path:       filepath.ToSlash(f.RelPath),
+		Type:       f.Type,
+		Executable: info.Mode()&0111 != 0,
+		Content:    string(data),
+	}, nil
+}
+
+// Write encodes the export in the given format.
+func (e *Export) Write(w io.Writer, format ExportFormat) error {
+	switch format {
+	case ExportJSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(e)
+	case ExportTar:
+		return e.writeTar(w)
+	}
+	return fmt.Errorf("unknown export format %q", format)
+}
+
+// writeTar writes the export as a tar archive. The manifest holds the
+// export without file contents, which follow as regular entries.
+func (e *Export) writeTar(w io.Writer) error {
+	manifest := *e
+	manifest.Skills = make([]ExportedSkill, len(e.Skills))
+	for i, s := range e.Skills {
+		s.Content = ""
+		s.Files = slices.Clone(s.Files)
+		for j := range s.Files {
+			s.Files[j].Content = ""
+		}
+		manifest.Skills[i] = s
+	}
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tw := tar.NewWriter(w)
+	add := func(name string, data []byte, mode int64) error {
+		hdr := &tar.Header{Name: name, Mode: mode, Size: int64(len(data)), ModTime: e.ExportedAt}
+		if err := tw.WriteHeader(hdr); err != nil {
+			return err
+		}
+		_, err := tw.Write(data)
+		return err
+	}
+
+	if err := add(exportManifest, append(data, '\n'), 0644); err != nil {
+		return err
+	}
+	for _, s := range e.Skills {
+		dir := s.exportDir()
+		md, err := s.skillMD()
+		if err != nil {
+			return err
+		}
+		if err := add(path.Join(dir, SkillFileName), md, 0644); err != nil {
+			return err
+		}
+		for _, f := range s.Files {
+			if err := add(path.Join(dir, f.Path), []byte(f.Content), f.mode()); err != nil {
+				return err
+			}
+		}
+	}
+	return tw.Close()
+}
+
+// ReadExport decodes an export written in either format.
+func ReadExport(r io.Reader) (*Export, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	// JSON exports start with an object; anything else must be a tar archive
+	var exp *Export
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '{' {
+		exp = &Export{}
+		if err := json.Unmarshal(trimmed, exp); err != nil {
+			return nil, fmt.Errorf("invalid export: %w", err)
+		}
+	} else if exp, err = readTarExport(data); err != nil {
+		return nil, fmt.Errorf("invalid export: %w", err)
+	}
+
+	if err := exp.validate(); err != nil {
+		return nil, err
+	}
+	return exp, nil
+}
+
+// readTarExport decodes a tar export.
+func readTarExport(data []byte) (*Export, error) {
+	entries := make(map[string][]byte)
+	tr := tar.NewReader(bytes.NewReader(data))
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if hdr.Typeflag != tar.TypeReg {
+			continue
+		}
+		content, err := io.ReadAll(tr)
+		if err != nil {
+			return nil, err
+		}
+		entries[path.Clean(hdr.Name)] = content
+	}
+
+	manifest, ok := entries[exportManifest]
+	if !ok {
+		return nil, fmt.Errorf("missing %s", exportManifest)
+	}
+	exp := &Export{}
+	if err := json.Unmarshal(manifest, exp); err != nil {
+		return nil, err
+	}
+
+	parser := NewParser()
+	for i := range exp.Skills {
+		s := &exp.Skills[i]
+		dir := s.exportDir()
+
+		md, ok := entries[path.Join(dir, SkillFileName)]
+		if !ok {
+			return nil, fmt.Errorf("missing %s", path.Join(dir, SkillFileName))
+		}
+		_, body, err := parser.Parse(md)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path.Join(dir, SkillFileName), err)
+		}
+		s.Content = body
+
+		for j := range s.Files {
+			f := &s.Files[j]
+			content, ok := entries[path.Join(dir, f.Path)]
+			if !ok {
+				return nil, fmt.Errorf("missing %s", path.Join(dir, f.Path))
+			}
+			f.Content = string(content)
+		}
+	}
+	return exp, nil
+}
+
+// validate checks that the export can be extracted safely.
+func (e *Export) validate() error {
+	if e.Version != ExportVersion {
+		return fmt.Errorf("unsupported export version %d", e.Version)
+	}
+	for _, s := range e.Skills {
+		fm := Frontmatter{Name: s.Name, Description: s.Description}
+		if err := fm.Validate(); err != nil {
+			return &SkillError{SkillPath: s.Ref(), Message: "invalid exported skill", Err: err}
+		}
+		if ref := s.Ref(); !filepath.IsLocal(ref) || filepath.Base(ref) != ref {
+			return &SkillError{SkillPath: ref, Message: "invalid exported skill name"}
+		}
+		for _, f := range s.Files {
+			if !filepath.IsLocal(filepath.FromSlash(f.Path)) || f.Path == SkillFileName {
+				return &SkillError{SkillPath: s.Ref(), Message: fmt.Sprintf("invalid exported file path %q", f.Path)}
+			}
+		}
+	}
+	return nil
+}
+
+// Extract writes the exported skills as skill directories below dir, in
+// dir/global and dir/project. It fails without writing anything if one of
+// the skill directories already exists.
+func (e *Export) Extract(dir string) error {
+	for _, s := range e.Skills {
+		target := filepath.Join(dir, filepath.FromSlash(s.exportDir()))
+		if _, err := os.Stat(target); err == nil {
+			return &SkillError{SkillPath: target, Message: fmt.Sprintf("skill directory %s already exists", target)}
+		}
+	}
+
+	for _, s := range e.Skills {
+		target := filepath.Join(dir, filepath.FromSlash(s.exportDir()))
+		md, err := s.skillMD()
+		if err != nil {
+			return err
+		}
+		if err := os.MkdirAll(target, 0755); err != nil {
+			return err
+		}
+		if err := os.WriteFile(filepath.Join(target, SkillFileName), md, 0644); err != nil {
+			return err
+		}
+
+		for _, f := range s.Files {
+			file := filepath.Join(target, filepath.FromSlash(f.Path))
+			if err := os.MkdirAll(filepath.Dir(file), 0755); err != nil {
+				return err
+			}
+			if err := os.WriteFile(file, []byte(f.Content), os.FileMode(f.mode())); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// LoadExport loads a registry that serves exactly the skills of an export.
+// The export is extracted below dir, which should be empty, and the
+// registry reads it like any skills directory, without a skills config.
+// Version pins recorded in the export are applied before opts.
+func LoadExport(ctx context.Context, r io.Reader, dir string, opts ...RegistryOption) (*Registry, error) {
+	exp, err := ReadExport(r)
+	if err != nil {
+		return nil, err
+	}
+	if err := exp.Extract(dir); err != nil {
+		return nil, err
+	}
+
+	loader := NewLoader(
+		WithGlobalSkillsDir(filepath.Join(dir, string(SourceGlobal))),
+		WithProjectSkillsDir(filepath.Join(dir, string(SourceProject))),
+		WithConfigFile(""),
+	)
+	registry := NewRegistry(loader, append([]RegistryOption{WithVersionPins(exp.Pins)}, opts...)...)
+	if err := registry.Initialize(ctx); err != nil {
+		return nil, err
+	}
+	return registry, nil
+}
+
+// exportDir returns the slash-separated directory of the skill within an
+// export. Skills from sources other than the global directory are
+// exported as project skills.
+func (s ExportedSkill) exportDir() string {
+	source := SourceProject
+	if s.Source == SourceGlobal {
+		source = SourceGlobal
+	}
+	return path.Join(string(source), s.Ref())
+}
+
+// skillMD renders the SKILL.md of an exported skill from its metadata and
+// frontmatter fields.
+func (s ExportedSkill) skillMD() ([]byte, error) {
+	fm, err := yaml.Marshal(Frontmatter{
+		Name:         s.Name,
+		Description:  s.Description,
+		AllowedTools: s.AllowedTools,
+		Version:      s.Version,
+		Tags:         s.Tags,
+		Triggers:     s.Triggers,
+		DependsOn:    s.DependsOn,
+		Extends:      s.Extends,
+		Deprecated:   s.Deprecated,
+		ReplacedBy:   s.ReplacedBy,
+		Author:       s.Author,
+		License:      s.License,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return []byte("---\n" + string(fm) + "---\n\n" + s.Content + "\n"), nil
+}
+
+// mode returns the file mode of an exported file.
+func (f ExportedFile) mode() int64 {
+	if f.Executable {
+		return 0755
+	}
+	return 0644
+}
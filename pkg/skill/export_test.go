@@ -0,0 +1,117 @@
+package skill
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestExportRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	global := filepath.Join(dir, "global")
+	project := filepath.Join(dir, "project")
+	writeTestSkill(t, filepath.Join(global, "notes"), "notes", "Take meeting notes", "")
+	notesMD := "---\nname: notes\ndescription: Take meeting notes\nallowed-tools: [Read, Write]\nauthor: Docs Team\nlicense: MIT\n---\n\n# notes\n"
+	if err := os.WriteFile(filepath.Join(global, "notes", SkillFileName), []byte(notesMD), 0644); err != nil {
+		t.Fatal(err)
+	}
+	writeTestSkill(t, filepath.Join(project, "deploy@1.0.0"), "deploy", "Deploy services", "1.0.0")
+	writeTestSkill(t, filepath.Join(project, "deploy@2.0.0"), "deploy", "Deploy services v2", "2.0.0")
+	if err := os.MkdirAll(filepath.Join(project, "deploy@1.0.0", "scripts"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(project, "deploy@1.0.0", "scripts", "run.sh"), []byte("#!/bin/sh\necho deploy\n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(project, "deploy@1.0.0", "logo.png"), []byte{0x89, 'P', 'N', 'G', 0}, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	registry := NewRegistry(NewLoader(WithGlobalSkillsDir(global), WithProjectSkillsDir(project)),
+		WithVersionPins(map[string]string{"deploy": "1.0.0"}))
+	if err := registry.Initialize(ctx); err != nil {
+		t.Fatalf("Initialize() error: %v", err)
+	}
+
+	for _, format := range []ExportFormat{ExportJSON, ExportTar} {
+		t.Run(string(format), func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := registry.Export(ctx, &buf, format); err != nil {
+				t.Fatalf("Export() error: %v", err)
+			}
+
+			imported, err := LoadExport(ctx, &buf, t.TempDir())
+			if err != nil {
+				t.Fatalf("LoadExport() error: %v", err)
+			}
+
+			names := imported.Names()
+			slices.Sort(names)
+			if !slices.Equal(names, []string{"deploy", "notes"}) {
+				t.Errorf("Names() = %v, want [deploy notes]", names)
+			}
+			if versions := imported.Versions("deploy"); len(versions) != 2 {
+				t.Errorf("Versions(deploy) = %d versions, want 2", len(versions))
+			}
+
+			deploy, err := imported.Get(ctx, "deploy")
+			if err != nil {
+				t.Fatalf("Get(deploy) error: %v", err)
+			}
+			if deploy.Version != "1.0.0" {
+				t.Errorf("default version = %s, want pinned 1.0.0", deploy.Version)
+			}
+			if len(deploy.Files) != 1 || filepath.ToSlash(deploy.Files[0].RelPath) != "scripts/run.sh" {
+				t.Fatalf("Files = %v, want only scripts/run.sh", deploy.Files)
+			}
+			if info, err := os.Stat(deploy.Files[0].AbsPath); err != nil || info.Mode()&0111 == 0 {
+				t.Errorf("scripts/run.sh lost its executable mode: %v", err)
+			}
+
+			content, err := imported.GetContent(ctx, "deploy@2.0.0")
+			if err != nil {
+				t.Fatalf("GetContent() error: %v", err)
+			}
+			want, _ := registry.GetContent(ctx, "deploy@2.0.0")
+			if content != want {
+				t.Errorf("content = %q, want %q", content, want)
+			}
+
+			notes, err := imported.Resolve("notes")
+			if err != nil || notes.Source != SourceGlobal {
+				t.Errorf("Resolve(notes) = %+v, %v; want a global skill", notes, err)
+			}
+			if s, err := imported.Get(ctx, "notes"); err != nil || !slices.Equal(s.AllowedTools, []string{"Read", "Write"}) || s.Author != "Docs Team" || s.License != "MIT" {
+				t.Errorf("Get(notes) = %+v, %v; want allowed-tools, author and license kept", s, err)
+			}
+			if got, want := imported.GenerateSystemPromptSection(), registry.GenerateSystemPromptSection(); strings.Count(got, "<skill>") != strings.Count(want, "<skill>") {
+				t.Errorf("prompt sections differ:\n%s\nvs\n%s", got, want)
+			}
+		})
+	}
+}
+
+func TestReadExportRejectsUnsafePaths(t *testing.T) {
+	tests := []struct {
+		name string
+		doc  string
+	}{
+		{name: "file outside skill", doc: `{"version":1,"skills":[{"name":"a","description":"A","content":"x","files":[{"path":"../../evil","type":"other"}]}]}`},
+		{name: "absolute file", doc: `{"version":1,"skills":[{"name":"a","description":"A","content":"x","files":[{"path":"/etc/passwd","type":"other"}]}]}`},
+		{name: "name with separator", doc: `{"version":1,"skills":[{"name":"../a","description":"A","content":"x"}]}`},
+		{name: "unknown version", doc: `{"version":9,"skills":[]}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ReadExport(strings.NewReader(tt.doc)); err == nil {
+				t.Error("ReadExport() accepted an unsafe export")
+			}
+		})
+	}
+}
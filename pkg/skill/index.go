@@ -0,0 +1,298 @@
+package skill
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// SkillMatch is a ranked search result.
+type SkillMatch struct {
+	Metadata SkillMetadata `json:"metadata"`
+	Score    float64       `json:"score"`
+}
+
+// BM25 parameters.
+const (
+	bm25K1 = 1.2
+	bm25B  = 0.75
+)
+
+// Field weights for the index. Name hits count most, mirroring how
+// users usually refer to a skill; weights also serve as match evidence.
+const (
+	weightName        = 3
+	weightTag         = 2
+	weightTrigger     = 2
+	weightDescription = 1
+
+	// minMatchEvidence is the summed field weight of distinct matched terms
+	// required before FindMatchingSkill suggests a skill: one name term, or
+	// one tag/trigger term, or two description terms.
+	minMatchEvidence = 2
+)
+
+// searchIndex is an inverted BM25 index over skill metadata.
+type searchIndex struct {
+	docs []SkillMetadata
+	bm25 *bm25Index
+}
+
+// newSearchIndex builds an index over names, descriptions, tags and triggers.
+func newSearchIndex(metadata []SkillMetadata) *searchIndex {
+	idx := &searchIndex{docs: make([]SkillMetadata, len(metadata))}
+	copy(idx.docs, metadata)
+
+	fields := make([][]indexField, len(idx.docs))
+	for i, m := range idx.docs {
+		fields[i] = append(fields[i], indexField{m.Name, weightName}, indexField{m.Description, weightDescription})
+		for _, tag := range m.Tags {
+			fields[i] = append(fields[i], indexField{tag, weightTag})
+		}
+		for _, trigger := range m.Triggers {
+			fields[i] = append(fields[i], indexField{trigger, weightTrigger})
+		}
+	}
+	idx.bm25 = newBM25Index(fields)
+
+	return idx
+}
+
+// search returns up to k documents ranked by BM25 score, best first.
+// Only documents with at least minEvidence match evidence are returned.
+// A k <= 0 returns all matches.
+func (idx *searchIndex) search(query string, k, minEvidence int) []SkillMatch {
+	if idx == nil || len(idx.docs) == 0 {
+		return nil
+	}
+
+	matches := make([]SkillMatch, 0)
+	for _, hit := range idx.bm25.search(query, minEvidence) {
+		matches = append(matches, SkillMatch{Metadata: idx.docs[hit.doc], Score: hit.score})
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		if matches[i].Score != matches[j].Score {
+			return matches[i].Score > matches[j].Score
+		}
+		return matches[i].Metadata.Name < matches[j].Metadata.Name
+	})
+
+	if k > 0 && len(matches) > k {
+		matches = matches[:k]
+	}
+	return matches
+}
+
+// indexField is a piece of document text with its field weight.
+type indexField struct {
+	text   string
+	weight int
+}
+
+// bm25Index is a generic inverted index with BM25 scoring over
+// documents made of weighted fields.
+type bm25Index struct {
+	postings map[string][]posting
+	lengths  []float64
+	avgLen   float64
+}
+
+// posting records a term occurrence in one document.
+type posting struct {
+	doc int
+	// tf is the weighted term frequency
+	tf float64
+	// weight is the highest field weight the term appeared in
+	weight int
+}
+
+// bm25Hit is a scored document.
+type bm25Hit struct {
+	doc   int
+	score float64
+}
+
+// newBM25Index indexes documents; each document is a list of weighted fields.
+func newBM25Index(docs [][]indexField) *bm25Index {
+	idx := &bm25Index{
+		postings: make(map[string][]posting),
+		lengths:  make([]float64, len(docs)),
+	}
+
+	total := 0.0
+	for i, fields := range docs {
+		tf := make(map[string]float64)
+		best := make(map[string]int)
+		for _, f := range fields {
+			for _, term := range tokenize(f.text) {
+				tf[term] += float64(f.weight)
+				idx.lengths[i] += float64(f.weight)
+				if f.weight > best[term] {
+					best[term] = f.weight
+				}
+			}
+		}
+
+		for term, freq := range tf {
+			idx.postings[term] = append(idx.postings[term], posting{doc: i, tf: freq, weight: best[term]})
+		}
+		total += idx.lengths[i]
+	}
+
+	if len(docs) > 0 {
+		idx.avgLen = total / float64(len(docs))
+	}
+
+	return idx
+}
+
+// search scores every document sharing a term with the query. Documents
+// whose summed field weight of matched terms is below minEvidence are
+// dropped. Hits are returned in document order.
+func (idx *bm25Index) search(query string, minEvidence int) []bm25Hit {
+	if len(idx.lengths) == 0 || idx.avgLen == 0 {
+		return nil
+	}
+
+	scores := make(map[int]float64)
+	evidence := make(map[int]int)
+	n := float64(len(idx.lengths))
+
+	seen := make(map[string]bool)
+	for _, term := range tokenize(query) {
+		if seen[term] {
+			continue
+		}
+		seen[term] = true
+
+		postings := idx.postings[term]
+		if len(postings) == 0 {
+			continue
+		}
+
+		df := float64(len(postings))
+		idf := math.Log(1 + (n-df+0.5)/(df+0.5))
+		for _, p := range postings {
+			norm := 1 - bm25B + bm25B*idx.lengths[p.doc]/idx.avgLen
+			scores[p.doc] += idf * p.tf * (bm25K1 + 1) / (p.tf + bm25K1*norm)
+			evidence[p.doc] += p.weight
+		}
+	}
+
+	hits := make([]bm25Hit, 0, len(scores))
+	for doc, score := range scores {
+		if evidence[doc] >= minEvidence {
+			hits = append(hits, bm25Hit{doc: doc, score: score})
+		}
+	}
+	sort.Slice(hits, func(i, j int) bool { return hits[i].doc < hits[j].doc })
+
+	return hits
+}
+
+// stopWords are common English words ignored when indexing and querying.
+var stopWords = map[string]bool{
+	"a": true, "an": true, "and": true, "are": true, "as": true, "at": true,
+	"be": true, "by": true, "can": true, "do": true, "for": true, "from": true,
+	"help": true, "how": true, "i": true, "in": true, "is": true, "it": true,
+	"me": true, "my": true, "of": true, "on": true, "or": true, "please": true,
+	"the": true, "this": true, "to": true, "use": true, "want": true, "what": true,
+	"when": true, "with": true, "you": true, "your": true,
+}
+
+// tokenize splits text into normalized search terms. Latin words are
+// lowercased, split on punctuation (so "git-commit" yields "git" and
+// "commit"), stop-word filtered and stemmed. Runs of CJK characters are
+// split into overlapping bigrams, since CJK text has no word separators.
+func tokenize(text string) []string {
+	var (
+		terms []string
+		word  []rune
+		cjk   []rune
+	)
+
+	flushWord := func() {
+		if len(word) > 0 {
+			w := string(word)
+			if !stopWords[w] {
+				terms = append(terms, stem(w))
+			}
+			word = word[:0]
+		}
+	}
+	flushCJK := func() {
+		switch {
+		case len(cjk) == 1:
+			terms = append(terms, string(cjk))
+		case len(cjk) > 1:
+			for i := 0; i+1 < len(cjk); i++ {
+				terms = append(terms, string(cjk[i:i+2]))
+			}
+		}
+		cjk = cjk[:0]
+	}
+
+	for _, r := range text {
+		switch {
+		case isCJK(r):
+			flushWord()
+			cjk = append(cjk, r)
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			flushCJK()
+			word = append(word, unicode.ToLower(r))
+		default:
+			flushWord()
+			flushCJK()
+		}
+	}
+	flushWord()
+	flushCJK()
+
+	return terms
+}
+
+// isCJK reports whether r is a Chinese, Japanese or Korean character.
+func isCJK(r rune) bool {
+	return unicode.Is(unicode.Han, r) ||
+		unicode.Is(unicode.Hiragana, r) ||
+		unicode.Is(unicode.Katakana, r) ||
+		unicode.Is(unicode.Hangul, r)
+}
+
+// stem reduces an English word to a crude stem so that inflections match
+// ("commits", "committing" and "commit" share a stem). It only strips
+// common suffixes and is not a full Porter stemmer.
+func stem(w string) string {
+	if len(w) <= 3 {
+		return w
+	}
+
+	switch {
+	case strings.HasSuffix(w, "ies") && len(w) > 4:
+		w = w[:len(w)-3] + "y"
+	case strings.HasSuffix(w, "sses"):
+		w = w[:len(w)-2]
+	case strings.HasSuffix(w, "s") && !strings.HasSuffix(w, "ss") && !strings.HasSuffix(w, "us"):
+		w = w[:len(w)-1]
+	}
+
+	for _, suffix := range []string{"ing", "ed"} {
+		if strings.HasSuffix(w, suffix) && len(w)-len(suffix) >= 3 {
+			w = w[:len(w)-len(suffix)]
+			// Undouble final consonant: "committ" -> "commit"
+			if n := len(w); n >= 2 && w[n-1] == w[n-2] && !strings.ContainsRune("aeiouls", rune(w[n-1])) {
+				w = w[:n-1]
+			}
+			break
+		}
+	}
+
+	// Drop a silent final "e" so "stage" and "staged" share a stem
+	if strings.HasSuffix(w, "e") && len(w) > 4 {
+		w = w[:len(w)-1]
+	}
+
+	return w
+}
@@ -0,0 +1,67 @@
+package skill
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestTokenize(t *testing.T) {
+	tests := []struct {
+		text     string
+		expected []string
+	}{
+		{"Git-Commit helper", []string{"git", "commit", "helper"}},
+		{"committing staged changes", []string{"commit", "stag", "chang"}},
+		{"帮我提交代码", []string{"帮我", "我提", "提交", "交代", "代码"}},
+		{"写 git 提交信息", []string{"写", "git", "提交", "交信", "信息"}},
+		{"the skill for a digital", []string{"skill", "digital"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			if result := tokenize(tt.text); !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("tokenize(%q) = %q, want %q", tt.text, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestFindMatchingSkills(t *testing.T) {
+	registry := NewRegistry(NewLoader())
+	metadata := []SkillMetadata{
+		{Name: "git-commit", Description: "Write conventional commit messages for staged changes", Triggers: []string{"提交代码"}},
+		{Name: "digital-signage", Description: "Manage digital displays and screens"},
+		{Name: "changelog", Description: "Generate a changelog from commit history", Tags: []string{"release"}},
+	}
+	registry.snapshot.Store(&Snapshot{registry: registry, metadata: metadata, index: newSearchIndex(metadata)})
+
+	tests := []struct {
+		name     string
+		query    string
+		expected []string
+	}{
+		{name: "name match", query: "help me commit", expected: []string{"git-commit"}},
+		{name: "description terms add up", query: "commit history", expected: []string{"changelog", "git-commit"}},
+		{name: "no substring false positive", query: "git", expected: []string{"git-commit"}},
+		{name: "chinese trigger", query: "帮我提交代码", expected: []string{"git-commit"}},
+		{name: "tag match", query: "prepare the release", expected: []string{"changelog"}},
+		{name: "weak description match", query: "screens", expected: nil},
+		{name: "no match", query: "kubernetes", expected: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var names []string
+			for _, m := range registry.FindMatchingSkills(tt.query, 0) {
+				names = append(names, m.Metadata.Name)
+			}
+			if !reflect.DeepEqual(names, tt.expected) {
+				t.Errorf("FindMatchingSkills(%q) = %v, want %v", tt.query, names, tt.expected)
+			}
+		})
+	}
+
+	if m := registry.FindMatchingSkill("write a commit message"); m == nil || m.Name != "git-commit" {
+		t.Errorf("FindMatchingSkill() = %v, want git-commit", m)
+	}
+}
@@ -0,0 +1,167 @@
+package skill
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// ErrInheritanceCycle is returned for skills that extend themselves,
+// directly or through their bases.
+var ErrInheritanceCycle = &SkillError{Message: "skill inheritance cycle"}
+
+// Section directives, written at the end of a heading in a derived skill,
+// e.g. "## Conventions {append}". A heading without directive replaces the
+// base section.
+const (
+	directiveReplace = "{replace}"
+	directiveAppend  = "{append}"
+	directiveRemove  = "{remove}"
+)
+
+// inherit returns the skill with the content and bundled files of its base
+// skills merged in; skills without a base are returned as is. chain holds
+// the references of the derived skills already visited.
+//
+// The merged document is the base document with every section of the
+// derived skill applied by heading (case-insensitively): sections are
+// replaced, appended to with {append} or dropped with {remove}, and
+// sections the base does not have are added at the end. The title and
+// introduction before the first section are taken from the derived skill
+// unless it has none. Sections are delimited by level-2 headings. Bundled
+// files of the derived skill take precedence over base files at the same
+// path.
+func (s *Snapshot) inherit(ctx context.Context, skill *Skill, chain []string) (*Skill, error) {
+	if skill.Extends == "" {
+		return skill, nil
+	}
+
+	ref := skill.ToMetadata().Ref()
+	chain = append(chain, ref)
+
+	// Bases are resolved in the whole registry, so a view may list a
+	// derived skill without its base
+	bases := s
+	if s.unfiltered != nil {
+		bases = s.unfiltered
+	}
+	base, err := bases.get(ctx, skill.Extends)
+	if err != nil {
+		return nil, &SkillError{SkillPath: ref, Message: fmt.Sprintf("base skill %s not found", skill.Extends), Err: err}
+	}
+	if baseRef := base.ToMetadata().Ref(); slices.Contains(chain, baseRef) {
+		return nil, &SkillError{SkillPath: ref, Message: ErrInheritanceCycle.Message + ": " + strings.Join(append(chain, baseRef), " -> ")}
+	}
+	if base, err = bases.inherit(ctx, base, chain); err != nil {
+		return nil, err
+	}
+	baseContent, err := bases.skillContent(ctx, base)
+	if err != nil {
+		return nil, err
+	}
+
+	merged := *skill
+	merged.Content = mergeSections(baseContent, skill.Content)
+	merged.Files = mergeFiles(base.Files, skill.Files)
+	return &merged, nil
+}
+
+// docSection is a level-2 section of a markdown document.
+type docSection struct {
+	heading   string
+	directive string
+	lines     []string // including the heading line
+}
+
+// splitSections splits a markdown body into the lines before its first
+// section and its sections. Level-2 headings start sections, as do level-1
+// headings after the first section; the title is part of the head.
+func splitSections(body string) ([]string, []docSection) {
+	lines := strings.Split(body, "\n")
+	var starts []mdHeading
+	for _, h := range scanHeadings(lines) {
+		if h.level == 2 || (h.level == 1 && len(starts) > 0) {
+			starts = append(starts, h)
+		}
+	}
+	if len(starts) == 0 {
+		return lines, nil
+	}
+
+	sections := make([]docSection, len(starts))
+	for i, h := range starts {
+		end := len(lines)
+		if i+1 < len(starts) {
+			end = starts[i+1].line
+		}
+		heading, directive := h.text, ""
+		for _, d := range []string{directiveReplace, directiveAppend, directiveRemove} {
+			if text, ok := strings.CutSuffix(heading, d); ok {
+				heading, directive = strings.TrimSpace(text), d
+				break
+			}
+		}
+		sections[i] = docSection{heading: heading, directive: directive, lines: lines[h.line:end]}
+		if directive != "" {
+			// Drop the directive from the merged document
+			sections[i].lines = append([]string{strings.Repeat("#", h.level) + " " + heading}, sections[i].lines[1:]...)
+		}
+	}
+	return lines[:starts[0].line], sections
+}
+
+// mergeSections applies the sections of a derived skill to the content of
+// its base; see inherit.
+func mergeSections(base, child string) string {
+	baseHead, baseSections := splitSections(base)
+	childHead, childSections := splitSections(child)
+
+	head := baseHead
+	if strings.TrimSpace(strings.Join(childHead, "\n")) != "" {
+		head = childHead
+	}
+
+	merged := slices.Clone(baseSections)
+	var added []docSection
+	for _, cs := range childSections {
+		i := slices.IndexFunc(merged, func(bs docSection) bool {
+			return strings.EqualFold(bs.heading, cs.heading)
+		})
+		switch {
+		case cs.directive == directiveRemove:
+			if i >= 0 {
+				merged = slices.Delete(merged, i, i+1)
+			}
+		case i < 0:
+			added = append(added, cs)
+		case cs.directive == directiveAppend:
+			body := strings.TrimSpace(strings.Join(cs.lines[1:], "\n"))
+			merged[i].lines = []string{strings.TrimSpace(strings.Join(merged[i].lines, "\n")), "", body}
+		default:
+			merged[i] = cs
+		}
+	}
+
+	parts := []string{strings.TrimSpace(strings.Join(head, "\n"))}
+	for _, sec := range append(merged, added...) {
+		parts = append(parts, strings.TrimSpace(strings.Join(sec.lines, "\n")))
+	}
+	parts = slices.DeleteFunc(parts, func(p string) bool { return p == "" })
+	return strings.Join(parts, "\n\n")
+}
+
+// mergeFiles merges the bundled files of a base and a derived skill, the
+// derived skill's files replacing base files at the same path.
+func mergeFiles(base, child []SkillFile) []SkillFile {
+	merged := slices.Clone(child)
+	for _, f := range base {
+		if !slices.ContainsFunc(child, func(c SkillFile) bool { return c.RelPath == f.RelPath }) {
+			merged = append(merged, f)
+		}
+	}
+	slices.SortFunc(merged, func(a, b SkillFile) int {
+		return strings.Compare(a.RelPath, b.RelPath)
+	})
+	return merged
+}
@@ -0,0 +1,130 @@
+package skill
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestMergeSections(t *testing.T) {
+	base := "# git-commit\n\nCommit changes.\n\n## Conventions\n\nUse imperative mood.\n\n### Scope\n\nOptional.\n\n## Steps\n\n1. Stage\n2. Commit\n\n## Signing\n\nSign commits."
+
+	tests := []struct {
+		name     string
+		child    string
+		expected string
+	}{
+		{
+			name:     "empty child keeps base",
+			child:    "",
+			expected: base,
+		},
+		{
+			name:     "replace section with subsections",
+			child:    "## conventions\n\nUse Conventional Commits.",
+			expected: "# git-commit\n\nCommit changes.\n\n## conventions\n\nUse Conventional Commits.\n\n## Steps\n\n1. Stage\n2. Commit\n\n## Signing\n\nSign commits.",
+		},
+		{
+			name:     "append and remove",
+			child:    "## Steps {append}\n\n3. Push\n\n## Signing {remove}",
+			expected: "# git-commit\n\nCommit changes.\n\n## Conventions\n\nUse imperative mood.\n\n### Scope\n\nOptional.\n\n## Steps\n\n1. Stage\n2. Commit\n\n3. Push",
+		},
+		{
+			name:     "child title and new section",
+			child:    "# acme-commit\n\nCommit in acme repos.\n\n## Tickets\n\nReference the ticket.\n\n## Missing {remove}",
+			expected: "# acme-commit\n\nCommit in acme repos.\n\n## Conventions\n\nUse imperative mood.\n\n### Scope\n\nOptional.\n\n## Steps\n\n1. Stage\n2. Commit\n\n## Signing\n\nSign commits.\n\n## Tickets\n\nReference the ticket.",
+		},
+		{
+			name:     "explicit replace drops directive",
+			child:    "## Signing {replace}\n\nDo not sign.\n\n```sh\n## not a heading {remove}\n```",
+			expected: "# git-commit\n\nCommit changes.\n\n## Conventions\n\nUse imperative mood.\n\n### Scope\n\nOptional.\n\n## Steps\n\n1. Stage\n2. Commit\n\n## Signing\n\nDo not sign.\n\n```sh\n## not a heading {remove}\n```",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := mergeSections(base, tt.child); got != tt.expected {
+				t.Errorf("mergeSections() =\n%s\n\nwant\n%s", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestRegistryInheritance(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	write := func(name, frontmatter, body string) {
+		t.Helper()
+		if err := os.MkdirAll(filepath.Join(dir, name, "references"), 0755); err != nil {
+			t.Fatal(err)
+		}
+		content := "---\nname: " + name + "\ndescription: Skill " + name + "\n" + frontmatter + "---\n\n" + body
+		if err := os.WriteFile(filepath.Join(dir, name, SkillFileName), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	writeFile := func(name, rel, content string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(dir, name, rel), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	write("git-commit", "", "# git-commit\n\n## Conventions\n\nImperative mood.\n\n## Steps\n\n1. Commit\n")
+	writeFile("git-commit", "references/style.md", "base style")
+	writeFile("git-commit", "references/hooks.md", "base hooks")
+	write("acme-commit", "extends: git-commit\n", "## Conventions\n\nConventional Commits.\n")
+	writeFile("acme-commit", "references/style.md", "acme style")
+	write("acme-release", "extends: acme-commit\n", "## Steps {append}\n\n2. Tag\n")
+	write("ping", "extends: pong\n", "## A\n")
+	write("pong", "extends: ping\n", "## B\n")
+	write("orphan", "extends: missing\n", "## A\n")
+
+	registry := NewRegistry(NewLoader(WithGlobalSkillsDir(filepath.Join(dir, "missing")), WithProjectSkillsDir(dir)))
+	if err := registry.Initialize(ctx); err != nil {
+		t.Fatalf("Initialize() error: %v", err)
+	}
+
+	content, err := registry.GetContent(ctx, "acme-release")
+	if err != nil {
+		t.Fatalf("GetContent(acme-release) error: %v", err)
+	}
+	if want := "# git-commit\n\n## Conventions\n\nConventional Commits.\n\n## Steps\n\n1. Commit\n\n2. Tag"; content != want {
+		t.Errorf("merged content =\n%s\n\nwant\n%s", content, want)
+	}
+	if section := NewParser().ExtractSection(content, "Conventions"); !strings.Contains(section, "Conventional Commits") {
+		t.Errorf("ExtractSection() on merged content = %q", section)
+	}
+
+	skill, err := registry.Get(ctx, "acme-commit")
+	if err != nil {
+		t.Fatalf("Get(acme-commit) error: %v", err)
+	}
+	files := make(map[string]string)
+	for _, f := range skill.Files {
+		data, _ := os.ReadFile(f.AbsPath)
+		files[filepath.ToSlash(f.RelPath)] = string(data)
+	}
+	if files["references/style.md"] != "acme style" || files["references/hooks.md"] != "base hooks" {
+		t.Errorf("merged files = %v, want acme style.md and base hooks.md", files)
+	}
+
+	// The base itself is unchanged
+	if base, _ := registry.GetContent(ctx, "git-commit"); !strings.Contains(base, "Imperative mood") {
+		t.Errorf("base content changed: %s", base)
+	}
+
+	for ref, want := range map[string]string{"ping": "skill inheritance cycle", "orphan": "base skill missing not found"} {
+		if _, err := registry.GetContent(ctx, ref); err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("GetContent(%s) error = %v, want %q", ref, err, want)
+		}
+	}
+
+	// A view resolves bases outside of it
+	view := registry.View(Filter{Names: []string{"acme-*"}})
+	if got, err := view.GetContent(ctx, "acme-commit"); err != nil || !strings.Contains(got, "## Steps") {
+		t.Errorf("view GetContent(acme-commit) = %q, %v", got, err)
+	}
+}
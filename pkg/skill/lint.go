@@ -0,0 +1,552 @@
+package skill
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// LintDiagnostic is a single finding reported by a lint rule.
+type LintDiagnostic struct {
+	// Rule is the name of the rule that produced the finding
+	Rule string `json:"rule"`
+
+	// Severity is the configured severity of the rule
+	Severity IssueSeverity `json:"severity"`
+
+	// Skill is the skill directory being linted
+	Skill string `json:"skill"`
+
+	// File is the file the finding refers to
+	File string `json:"file"`
+
+	// Line is the 1-based line in File, or 0 when not line-specific
+	Line int `json:"line,omitempty"`
+
+	// Message describes the finding
+	Message string `json:"message"`
+}
+
+// LintFinding is what a rule reports; the Linter fills in rule, severity and location.
+type LintFinding struct {
+	Line    int
+	File    string
+	Message string
+}
+
+// LintTarget is the skill under inspection passed to every rule.
+type LintTarget struct {
+	// Dir is the skill directory
+	Dir string
+
+	// Skill is the loaded skill, including bundled files
+	Skill *Skill
+
+	// Frontmatter is the parsed frontmatter
+	Frontmatter *Frontmatter
+
+	// Body is the markdown body after the frontmatter
+	Body string
+
+	// Lines are the raw lines of SKILL.md
+	Lines []string
+
+	// Deprecated are the other deprecated skills linted together with
+	// this one; empty when linting a single skill
+	Deprecated []SkillMetadata
+}
+
+// LineOf returns the 1-based line of the first SKILL.md line containing
+// substr, or 0 if it does not occur.
+func (t *LintTarget) LineOf(substr string) int {
+	for i, line := range t.Lines {
+		if strings.Contains(line, substr) {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+// LintOptions holds the rule-specific options from the lint configuration.
+type LintOptions map[string]any
+
+// Int returns an integer option or def if unset.
+func (o LintOptions) Int(key string, def int) int {
+	switch v := o[key].(type) {
+	case int:
+		return v
+	case float64:
+		return int(v)
+	}
+	return def
+}
+
+// Strings returns a string list option or def if unset.
+func (o LintOptions) Strings(key string, def []string) []string {
+	raw, ok := o[key].([]any)
+	if !ok {
+		if list, ok := o[key].([]string); ok {
+			return list
+		}
+		return def
+	}
+	result := make([]string, 0, len(raw))
+	for _, v := range raw {
+		result = append(result, fmt.Sprint(v))
+	}
+	return result
+}
+
+// LintRule is a named, individually configurable check.
+type LintRule struct {
+	// Name identifies the rule in configuration and output
+	Name string
+
+	// Description explains what the rule checks
+	Description string
+
+	// Severity is the default severity of the rule
+	Severity IssueSeverity
+
+	// Check inspects a skill and returns its findings
+	Check func(t *LintTarget, opts LintOptions) []LintFinding
+}
+
+// LintConfig selects and configures lint rules.
+type LintConfig struct {
+	Rules map[string]LintRuleConfig `yaml:"rules" json:"rules"`
+}
+
+// LintRuleConfig overrides the severity and options of one rule.
+type LintRuleConfig struct {
+	// Severity is "error", "warning" or "off"; empty keeps the default
+	Severity string `yaml:"severity,omitempty" json:"severity,omitempty"`
+
+	// Options are rule-specific settings
+	Options LintOptions `yaml:"options,omitempty" json:"options,omitempty"`
+}
+
+// LintSeverityOff disables a rule in LintRuleConfig.
+const LintSeverityOff = "off"
+
+// LoadLintConfig reads a YAML lint configuration file.
+func LoadLintConfig(path string) (*LintConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read lint config: %w", err)
+	}
+
+	var config LintConfig
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("failed to parse lint config %s: %w", path, err)
+	}
+	return &config, nil
+}
+
+// Linter runs a configured rule set against skill directories.
+type Linter struct {
+	rules  []LintRule
+	config LintConfig
+	loader *Loader
+	parser *Parser
+}
+
+// NewLinter creates a linter with DefaultLintRules and the given configuration.
+// A nil config enables every rule with its default severity.
+func NewLinter(config *LintConfig) (*Linter, error) {
+	l := &Linter{
+		rules:  DefaultLintRules(),
+		loader: NewLoader(),
+		parser: NewParser(),
+	}
+	if config != nil {
+		l.config = *config
+	}
+
+	known := make(map[string]bool, len(l.rules))
+	for _, r := range l.rules {
+		known[r.Name] = true
+	}
+	for name, rc := range l.config.Rules {
+		if !known[name] {
+			return nil, fmt.Errorf("unknown lint rule %q", name)
+		}
+		switch rc.Severity {
+		case "", LintSeverityOff, string(SeverityError), string(SeverityWarning):
+		default:
+			return nil, fmt.Errorf("invalid severity %q for lint rule %q", rc.Severity, name)
+		}
+	}
+
+	return l, nil
+}
+
+// Rules returns the rules known to the linter.
+func (l *Linter) Rules() []LintRule {
+	return l.rules
+}
+
+// severity returns the effective severity of a rule, or "" if disabled.
+func (l *Linter) severity(r LintRule) IssueSeverity {
+	rc, ok := l.config.Rules[r.Name]
+	if !ok || rc.Severity == "" {
+		return r.Severity
+	}
+	if rc.Severity == LintSeverityOff {
+		return ""
+	}
+	return IssueSeverity(rc.Severity)
+}
+
+// LintSkill lints a single skill directory.
+func (l *Linter) LintSkill(ctx context.Context, dir string) []LintDiagnostic {
+	return l.lintSkill(ctx, dir, nil)
+}
+
+// lintSkill lints a skill directory, checking references against the
+// deprecated skills linted together with it.
+func (l *Linter) lintSkill(ctx context.Context, dir string, deprecated []SkillMetadata) []LintDiagnostic {
+	skillMDPath := filepath.Join(dir, SkillFileName)
+	diag := func(rule string, line int, message string) LintDiagnostic {
+		return LintDiagnostic{
+			Rule:     rule,
+			Severity: SeverityError,
+			Skill:    dir,
+			File:     skillMDPath,
+			Line:     line,
+			Message:  message,
+		}
+	}
+
+	data, err := os.ReadFile(skillMDPath)
+	if err != nil {
+		return []LintDiagnostic{diag(lintRuleSkillMD, 0, "SKILL.md not found")}
+	}
+
+	fm, body, err := l.parser.Parse(data)
+	if err != nil {
+		return []LintDiagnostic{diag(lintRuleFrontmatter, 1, fmt.Sprintf("invalid frontmatter: %v", err))}
+	}
+
+	s, err := l.loader.LoadSkillFromDir(ctx, dir, SourceProject)
+	if err != nil {
+		return []LintDiagnostic{diag(lintRuleSkillMD, 0, fmt.Sprintf("failed to load skill: %v", err))}
+	}
+
+	target := &LintTarget{
+		Dir:         dir,
+		Skill:       s,
+		Frontmatter: fm,
+		Body:        body,
+		Lines:       strings.Split(string(data), "\n"),
+	}
+	for _, m := range deprecated {
+		if m.Name != fm.Name {
+			target.Deprecated = append(target.Deprecated, m)
+		}
+	}
+
+	var diagnostics []LintDiagnostic
+	for _, r := range l.rules {
+		severity := l.severity(r)
+		if severity == "" {
+			continue
+		}
+		for _, f := range r.Check(target, l.config.Rules[r.Name].Options) {
+			file := f.File
+			if file == "" {
+				file = skillMDPath
+			}
+			diagnostics = append(diagnostics, LintDiagnostic{
+				Rule:     r.Name,
+				Severity: severity,
+				Skill:    dir,
+				File:     file,
+				Line:     f.Line,
+				Message:  f.Message,
+			})
+		}
+	}
+
+	return diagnostics
+}
+
+// Lint lints every skill found under paths. See FindSkillDirs.
+func (l *Linter) Lint(ctx context.Context, paths []string) ([]LintDiagnostic, error) {
+	dirs, err := FindSkillDirs(paths)
+	if err != nil {
+		return nil, err
+	}
+
+	// References to deprecated skills are checked across all linted skills
+	var deprecated []SkillMetadata
+	for _, dir := range dirs {
+		fm, err := l.parser.ParseMetadataOnly(filepath.Join(dir, SkillFileName))
+		if err == nil && fm.Deprecated {
+			deprecated = append(deprecated, SkillMetadata{Name: fm.Name, Deprecated: true, ReplacedBy: fm.ReplacedBy, Path: dir})
+		}
+	}
+
+	var diagnostics []LintDiagnostic
+	for _, dir := range dirs {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		default:
+		}
+		diagnostics = append(diagnostics, l.lintSkill(ctx, dir, deprecated)...)
+	}
+	return diagnostics, nil
+}
+
+// FindSkillDirs expands paths into skill directories. A path is used as-is
+// when it contains SKILL.md; otherwise its immediate subdirectories that
+// contain SKILL.md are returned.
+func FindSkillDirs(paths []string) ([]string, error) {
+	var dirs []string
+	for _, p := range paths {
+		p = expandPath(p)
+		if _, err := os.Stat(filepath.Join(p, SkillFileName)); err == nil {
+			dirs = append(dirs, p)
+			continue
+		}
+
+		entries, err := os.ReadDir(p)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", p, err)
+		}
+		for _, entry := range entries {
+			if !entry.IsDir() {
+				continue
+			}
+			dir := filepath.Join(p, entry.Name())
+			if _, err := os.Stat(filepath.Join(dir, SkillFileName)); err == nil {
+				dirs = append(dirs, dir)
+			}
+		}
+	}
+
+	sort.Strings(dirs)
+	return dirs, nil
+}
+
+var (
+	// skillNamePattern is the recommended skill name format (kebab-case).
+	skillNamePattern = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+
+	// defaultWhenToUsePhrases indicate that a description says when to use the skill.
+	defaultWhenToUsePhrases = []string{"use when", "use this", "use for", "when to use", "when you", "when the user", "when asked", "use it"}
+
+	// defaultPlaceholders are the template texts left behind by "eino-skills create".
+	defaultPlaceholders = []string{
+		"Brief description of what this skill does",
+		"Describe what this skill does",
+		"[First Step]",
+		"[Second Step]",
+		"Detailed instructions for the first step",
+		"[Show a concrete example]",
+		"- Practice 1",
+	}
+)
+
+// Rules reported by the linter itself for skills it cannot load.
+const (
+	lintRuleSkillMD     = "skill-md"
+	lintRuleFrontmatter = "frontmatter"
+)
+
+// LoadLintRules describes the rules reported for skills the linter cannot
+// load. They are always errors, have no Check and cannot be configured.
+func LoadLintRules() []LintRule {
+	return []LintRule{
+		{Name: lintRuleSkillMD, Description: "Skill directory must contain a loadable SKILL.md", Severity: SeverityError},
+		{Name: lintRuleFrontmatter, Description: "SKILL.md frontmatter must be valid YAML", Severity: SeverityError},
+	}
+}
+
+// DefaultLintRules returns the built-in lint rules.
+func DefaultLintRules() []LintRule {
+	return []LintRule{
+		{
+			Name:        "name-format",
+			Description: "Skill name should be lowercase kebab-case",
+			Severity:    SeverityWarning,
+			Check: func(t *LintTarget, opts LintOptions) []LintFinding {
+				if skillNamePattern.MatchString(t.Frontmatter.Name) {
+					return nil
+				}
+				return []LintFinding{{
+					Line:    t.LineOf("name:"),
+					Message: fmt.Sprintf("skill name %q should be lowercase kebab-case", t.Frontmatter.Name),
+				}}
+			},
+		},
+		{
+			Name:        "name-dir-mismatch",
+			Description: "Skill name should match its directory name",
+			Severity:    SeverityError,
+			Check: func(t *LintTarget, opts LintOptions) []LintFinding {
+				dirName, _ := SplitSkillRef(filepath.Base(t.Dir))
+				if dirName == t.Frontmatter.Name {
+					return nil
+				}
+				return []LintFinding{{
+					Line:    t.LineOf("name:"),
+					Message: fmt.Sprintf("skill name %q does not match directory %q", t.Frontmatter.Name, filepath.Base(t.Dir)),
+				}}
+			},
+		},
+		{
+			Name:        "description-length",
+			Description: "Description should be long enough to select the skill (options: min, max)",
+			Severity:    SeverityWarning,
+			Check: func(t *LintTarget, opts LintOptions) []LintFinding {
+				n := len(t.Frontmatter.Description)
+				minLen, maxLen := opts.Int("min", 40), opts.Int("max", 500)
+				var msg string
+				switch {
+				case n < minLen:
+					msg = fmt.Sprintf("description is %d characters, shorter than %d", n, minLen)
+				case n > maxLen:
+					msg = fmt.Sprintf("description is %d characters, longer than %d", n, maxLen)
+				default:
+					return nil
+				}
+				return []LintFinding{{Line: t.LineOf("description:"), Message: msg}}
+			},
+		},
+		{
+			Name:        "when-to-use",
+			Description: "Description should say when to use the skill (options: phrases)",
+			Severity:    SeverityWarning,
+			Check: func(t *LintTarget, opts LintOptions) []LintFinding {
+				desc := strings.ToLower(t.Frontmatter.Description)
+				for _, phrase := range opts.Strings("phrases", defaultWhenToUsePhrases) {
+					if strings.Contains(desc, strings.ToLower(phrase)) {
+						return nil
+					}
+				}
+				return []LintFinding{{
+					Line:    t.LineOf("description:"),
+					Message: "description does not say when to use the skill (e.g. \"Use when ...\")",
+				}}
+			},
+		},
+		{
+			Name:        "missing-section",
+			Description: "Required sections must be present (options: sections)",
+			Severity:    SeverityWarning,
+			Check: func(t *LintTarget, opts LintOptions) []LintFinding {
+				parser := NewParser()
+				var findings []LintFinding
+				for _, section := range opts.Strings("sections", []string{"Instructions", "Examples"}) {
+					if parser.ExtractSection(t.Body, section) == "" {
+						findings = append(findings, LintFinding{Message: fmt.Sprintf("missing %q section", section)})
+					}
+				}
+				return findings
+			},
+		},
+		{
+			Name:        "content-length",
+			Description: "Instructions should not be trivially short (options: min)",
+			Severity:    SeverityWarning,
+			Check: func(t *LintTarget, opts LintOptions) []LintFinding {
+				if minLen := opts.Int("min", 100); len(t.Body) < minLen {
+					return []LintFinding{{Message: fmt.Sprintf("content is %d characters, shorter than %d", len(t.Body), minLen)}}
+				}
+				return nil
+			},
+		},
+		{
+			Name:        "todo-placeholder",
+			Description: "Template placeholders from \"eino-skills create\" must be replaced (options: placeholders)",
+			Severity:    SeverityError,
+			Check: func(t *LintTarget, opts LintOptions) []LintFinding {
+				var findings []LintFinding
+				placeholders := opts.Strings("placeholders", defaultPlaceholders)
+				for i, line := range t.Lines {
+					for _, p := range placeholders {
+						if strings.Contains(line, p) {
+							findings = append(findings, LintFinding{
+								Line:    i + 1,
+								Message: fmt.Sprintf("placeholder text %q left in skill", p),
+							})
+							break
+						}
+					}
+				}
+				return findings
+			},
+		},
+		{
+			Name:        "broken-reference",
+			Description: "Links and scripts/, references/, assets/ paths must resolve to bundled files",
+			Severity:    SeverityError,
+			Check: func(t *LintTarget, opts LintOptions) []LintFinding {
+				return referenceFindings(t, SeverityError)
+			},
+		},
+		{
+			Name:        "script-interpreter",
+			Description: "Bundled scripts must be executable or have a known interpreter",
+			Severity:    SeverityWarning,
+			Check: func(t *LintTarget, opts LintOptions) []LintFinding {
+				return referenceFindings(t, SeverityWarning)
+			},
+		},
+		{
+			Name:        "deprecation",
+			Description: "Deprecated skills should name their replacement with replaced-by",
+			Severity:    SeverityWarning,
+			Check: func(t *LintTarget, opts LintOptions) []LintFinding {
+				switch {
+				case t.Frontmatter.Deprecated && t.Frontmatter.ReplacedBy == "":
+					return []LintFinding{{Line: t.LineOf("deprecated:"), Message: "deprecated skill does not name a replacement (replaced-by)"}}
+				case !t.Frontmatter.Deprecated && t.Frontmatter.ReplacedBy != "":
+					return []LintFinding{{Line: t.LineOf("replaced-by:"), Message: "replaced-by is set but the skill is not deprecated"}}
+				}
+				return nil
+			},
+		},
+		{
+			Name:        "deprecated-reference",
+			Description: "Skills should not reference deprecated skills linted with them",
+			Severity:    SeverityWarning,
+			Check: func(t *LintTarget, opts LintOptions) []LintFinding {
+				var findings []LintFinding
+				for _, ref := range FindDeprecatedReferences(strings.Join(t.Lines, "\n"), t.Deprecated) {
+					findings = append(findings, LintFinding{Line: ref.Line, Message: ref.String()})
+				}
+				return findings
+			},
+		},
+	}
+}
+
+// referenceFindings converts CheckReferences issues of one severity to findings.
+func referenceFindings(t *LintTarget, severity IssueSeverity) []LintFinding {
+	issues, err := CheckReferences(t.Skill)
+	if err != nil {
+		return []LintFinding{{Message: err.Error()}}
+	}
+
+	var findings []LintFinding
+	for _, issue := range issues {
+		if issue.Severity != severity {
+			continue
+		}
+		f := LintFinding{Line: issue.Line, Message: issue.Message}
+		if issue.Line == 0 {
+			f.File = filepath.Join(t.Dir, issue.Target)
+		}
+		findings = append(findings, f)
+	}
+	return findings
+}
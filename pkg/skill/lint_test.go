@@ -0,0 +1,108 @@
+package skill
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestLinter(t *testing.T) {
+	root := t.TempDir()
+	writeTestSkill(t, filepath.Join(root, "good-skill"), "good-skill", "Formats commit messages. Use when the user asks to commit staged changes.", "")
+	writeTestSkill(t, filepath.Join(root, "other-dir"), "Bad_Name", "Does things", "")
+
+	tests := []struct {
+		name     string
+		config   *LintConfig
+		expected map[string]IssueSeverity
+	}{
+		{
+			name: "default rules",
+			expected: map[string]IssueSeverity{
+				"name-format":        SeverityWarning,
+				"name-dir-mismatch":  SeverityError,
+				"description-length": SeverityWarning,
+				"when-to-use":        SeverityWarning,
+				"missing-section":    SeverityWarning,
+				"content-length":     SeverityWarning,
+			},
+		},
+		{
+			name: "configured rules",
+			config: &LintConfig{Rules: map[string]LintRuleConfig{
+				"name-format":        {Severity: LintSeverityOff},
+				"when-to-use":        {Severity: "error", Options: LintOptions{"phrases": []any{"does"}}},
+				"description-length": {Options: LintOptions{"min": 5}},
+				"missing-section":    {Options: LintOptions{"sections": []any{"Instructions"}}},
+				"content-length":     {Severity: LintSeverityOff},
+			}},
+			expected: map[string]IssueSeverity{
+				"name-dir-mismatch": SeverityError,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			linter, err := NewLinter(tt.config)
+			if err != nil {
+				t.Fatalf("NewLinter() error: %v", err)
+			}
+
+			diagnostics, err := linter.Lint(context.Background(), []string{filepath.Join(root, "other-dir")})
+			if err != nil {
+				t.Fatalf("Lint() error: %v", err)
+			}
+
+			got := make(map[string]IssueSeverity)
+			for _, d := range diagnostics {
+				got[d.Rule] = d.Severity
+			}
+			if len(got) != len(tt.expected) {
+				t.Errorf("Lint() rules = %v, want %v", got, tt.expected)
+			}
+			for rule, severity := range tt.expected {
+				if got[rule] != severity {
+					t.Errorf("rule %s severity = %q, want %q", rule, got[rule], severity)
+				}
+			}
+		})
+	}
+
+	if dirs, err := FindSkillDirs([]string{root}); err != nil || len(dirs) != 2 {
+		t.Errorf("FindSkillDirs() = %v, %v; want 2 skill directories", dirs, err)
+	}
+
+	// Skills that cannot be loaded are reported under the load rules
+	broken := filepath.Join(t.TempDir(), "broken")
+	if err := os.MkdirAll(broken, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(broken, SkillFileName), []byte("---\nname: [broken\n---\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	linter, _ := NewLinter(nil)
+	diagnostics := linter.LintSkill(context.Background(), broken)
+	if len(diagnostics) != 1 || !slices.ContainsFunc(LoadLintRules(), func(r LintRule) bool { return r.Name == diagnostics[0].Rule }) {
+		t.Errorf("LintSkill(broken) = %v, want one diagnostic for a load rule", diagnostics)
+	}
+
+	// Only the texts of the create template are placeholders
+	conventions := filepath.Join(t.TempDir(), "conventions")
+	writeTestSkill(t, conventions, "conventions", "Code conventions", "")
+	if err := os.WriteFile(filepath.Join(conventions, SkillFileName),
+		[]byte("---\nname: conventions\ndescription: Code conventions\n---\n\n# Conventions\n\nMark open work with `// TODO(name)`.\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	for _, d := range linter.LintSkill(context.Background(), conventions) {
+		if d.Rule == "todo-placeholder" {
+			t.Errorf("LintSkill(conventions) reported %v", d)
+		}
+	}
+
+	if _, err := NewLinter(&LintConfig{Rules: map[string]LintRuleConfig{"no-such-rule": {}}}); err == nil {
+		t.Error("NewLinter() expected error for unknown rule")
+	}
+}
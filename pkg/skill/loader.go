@@ -2,12 +2,16 @@ package skill
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io/fs"
+	"log/slog"
 	"os"
 	"path/filepath"
 	"strings"
+	"sync/atomic"
 	"time"
+	"unicode/utf8"
 )
 
 // Loader handles discovering and loading skills from filesystem.
@@ -15,6 +19,12 @@ type Loader struct {
 	globalDir  string
 	projectDir string
 	parser     *Parser
+	logger     *slog.Logger
+
+	// configFile is the skills config; "" disables it
+	configFile string
+	configSet  bool
+	config     atomic.Pointer[Config]
 }
 
 // LoaderOption configures the Loader.
@@ -36,25 +46,64 @@ func WithProjectSkillsDir(dir string) LoaderOption {
 	}
 }
 
+// WithConfigFile sets the skills config file that enables, disables and
+// overrides skills. An empty path disables the config.
+// Default: skills.yaml next to the project skills directory (.eino/skills.yaml)
+func WithConfigFile(file string) LoaderOption {
+	return func(l *Loader) {
+		l.configFile = expandPath(file)
+		l.configSet = true
+	}
+}
+
 // NewLoader creates a new skills loader with the given options.
 func NewLoader(opts ...LoaderOption) *Loader {
 	l := &Loader{
 		globalDir:  expandPath("~/.eino/agent/skills"),
 		projectDir: ".eino/skills",
 		parser:     NewParser(),
+		logger:     discardLogger,
 	}
 
 	for _, opt := range opts {
 		opt(l)
 	}
+	if !l.configSet {
+		l.configFile = filepath.Join(filepath.Dir(l.projectDir), ConfigFileName)
+	}
 
 	return l
 }
 
+// ConfigFile returns the path of the skills config file, "" if disabled.
+func (l *Loader) ConfigFile() string {
+	return l.configFile
+}
+
+// loadConfig re-reads the skills config. The config is read on every full
+// scan, so edits take effect on the next Reload; the watcher reloads when
+// the config changes.
+func (l *Loader) loadConfig() (*Config, error) {
+	if l.configFile == "" {
+		return nil, nil
+	}
+	cfg, err := LoadConfig(l.configFile)
+	if err != nil {
+		return nil, err
+	}
+	l.config.Store(cfg)
+	return cfg, nil
+}
+
 // LoadAll loads all skills from both global and project directories.
-// Project skills take precedence over global skills with the same name.
+// Project skills take precedence over global skills with the same name: a
+// global skill with the same version is replaced, and other global versions
+// are returned but never become the default for the name.
 func (l *Loader) LoadAll(ctx context.Context) ([]*Skill, error) {
 	skills := make(map[string]*Skill)
+	if _, err := l.loadConfig(); err != nil {
+		return nil, err
+	}
 
 	// Load global skills first
 	globalSkills, err := l.loadFromDir(ctx, l.globalDir, SourceGlobal)
@@ -62,7 +111,7 @@ func (l *Loader) LoadAll(ctx context.Context) ([]*Skill, error) {
 		return nil, fmt.Errorf("failed to load global skills: %w", err)
 	}
 	for _, s := range globalSkills {
-		skills[s.Name] = s
+		skills[s.ToMetadata().Ref()] = s
 	}
 
 	// Load project skills (override global)
@@ -71,7 +120,7 @@ func (l *Loader) LoadAll(ctx context.Context) ([]*Skill, error) {
 		return nil, fmt.Errorf("failed to load project skills: %w", err)
 	}
 	for _, s := range projectSkills {
-		skills[s.Name] = s
+		skills[s.ToMetadata().Ref()] = s
 	}
 
 	// Convert map to slice
@@ -85,20 +134,33 @@ func (l *Loader) LoadAll(ctx context.Context) ([]*Skill, error) {
 
 // LoadMetadataOnly loads only skill metadata for system prompt injection.
 // This is more efficient as it doesn't load full content.
+// Every installed version of a skill is returned as a separate entry.
 func (l *Loader) LoadMetadataOnly(ctx context.Context) ([]SkillMetadata, error) {
+	metadata, _, err := l.scanMetadata(ctx)
+	return metadata, err
+}
+
+// scanMetadata loads metadata from both directories and also returns the
+// skill directories whose SKILL.md exists but does not parse or validate.
+func (l *Loader) scanMetadata(ctx context.Context) ([]SkillMetadata, []LoadFailure, error) {
+	start := time.Now()
 	metadata := make(map[string]SkillMetadata)
+	var failures []LoadFailure
+	if _, err := l.loadConfig(); err != nil {
+		return nil, nil, err
+	}
 
 	// Process global directory
-	if err := l.loadMetadataFromDir(ctx, l.globalDir, SourceGlobal, metadata); err != nil {
+	if err := l.loadMetadataFromDir(ctx, l.globalDir, SourceGlobal, metadata, &failures); err != nil {
 		if !os.IsNotExist(err) {
-			return nil, err
+			return nil, nil, err
 		}
 	}
 
 	// Process project directory (override global)
-	if err := l.loadMetadataFromDir(ctx, l.projectDir, SourceProject, metadata); err != nil {
+	if err := l.loadMetadataFromDir(ctx, l.projectDir, SourceProject, metadata, &failures); err != nil {
 		if !os.IsNotExist(err) {
-			return nil, err
+			return nil, nil, err
 		}
 	}
 
@@ -107,29 +169,60 @@ func (l *Loader) LoadMetadataOnly(ctx context.Context) ([]SkillMetadata, error)
 		result = append(result, m)
 	}
 
-	return result, nil
+	l.logger.Debug("scanned skill metadata",
+		slog.Int("skills", len(result)),
+		slog.Int("failures", len(failures)),
+		slog.Duration(logKeyDuration, time.Since(start)))
+
+	return result, failures, nil
 }
 
 // LoadSkill loads a specific skill by name.
-func (l *Loader) LoadSkill(ctx context.Context, name string) (*Skill, error) {
-	// Try project first
-	projectPath := filepath.Join(l.projectDir, name)
-	if skill, err := l.loadSingleSkill(ctx, projectPath, SourceProject); err == nil {
-		return skill, nil
+// The name may carry a version or semver range ("git-commit@^1.2"),
+// in which case the newest matching installed version is loaded. A bare
+// name loads the newest project version, falling back to global versions
+// like Registry.Get.
+func (l *Loader) LoadSkill(ctx context.Context, ref string) (*Skill, error) {
+	name, constraint := SplitSkillRef(ref)
+	metadata, err := l.LoadMetadataOnly(ctx)
+	if err != nil {
+		return nil, err
 	}
 
-	// Try global
-	globalPath := filepath.Join(l.globalDir, name)
-	if skill, err := l.loadSingleSkill(ctx, globalPath, SourceGlobal); err == nil {
-		return skill, nil
+	var candidates []SkillMetadata
+	for _, m := range metadata {
+		if m.Name == name {
+			candidates = append(candidates, m)
+		}
+	}
+
+	selected, ok := selectVersion(candidates, constraint)
+	if constraint == "" {
+		selected, ok = selectDefault(candidates, "")
+	}
+	if ok {
+		return l.loadSingleSkill(ctx, selected.Path, selected.Source)
+	}
+
+	if len(candidates) > 0 {
+		return nil, &SkillError{
+			SkillPath: ref,
+			Message:   ErrVersionNotFound.Message,
+		}
 	}
 
 	return nil, &SkillError{
-		SkillPath: name,
+		SkillPath: ref,
 		Message:   "skill not found",
 	}
 }
 
+// LoadSkillFromDir loads the skill in a specific directory, including its
+// bundled files. It is used for validating skills outside the configured roots.
+func (l *Loader) LoadSkillFromDir(ctx context.Context, dir string, source SkillSource) (*Skill, error) {
+	return l.loadSingleSkill(ctx, dir, source)
+}
+
 // LoadSkillContent loads the full content of a skill's SKILL.md.
 // Use this for on-demand loading when the skill is triggered.
 func (l *Loader) LoadSkillContent(ctx context.Context, skill *Skill) (string, error) {
@@ -153,6 +246,7 @@ func (l *Loader) loadFromDir(ctx context.Context, dir string, source SkillSource
 		return nil, err
 	}
 
+	cfg := l.config.Load()
 	var skills []*Skill
 	for _, entry := range entries {
 		if !entry.IsDir() {
@@ -169,7 +263,14 @@ func (l *Loader) loadFromDir(ctx context.Context, dir string, source SkillSource
 		skill, err := l.loadSingleSkill(ctx, skillPath, source)
 		if err != nil {
 			// Log but continue loading other skills
-			fmt.Fprintf(os.Stderr, "Warning: failed to load skill %s: %v\n", entry.Name(), err)
+			l.logger.Warn("failed to load skill",
+				slog.String(logKeySkill, entry.Name()),
+				slog.String(logKeyPath, skillPath),
+				slog.String(logKeySource, string(source)),
+				slog.Any("error", err))
+			continue
+		}
+		if !cfg.Allows(skill.ToMetadata()) {
 			continue
 		}
 
@@ -180,7 +281,8 @@ func (l *Loader) loadFromDir(ctx context.Context, dir string, source SkillSource
 }
 
 // loadMetadataFromDir loads only metadata from skills in a directory.
-func (l *Loader) loadMetadataFromDir(ctx context.Context, dir string, source SkillSource, metadata map[string]SkillMetadata) error {
+// Invalid skills are skipped and, if failures is non-nil, recorded there.
+func (l *Loader) loadMetadataFromDir(ctx context.Context, dir string, source SkillSource, metadata map[string]SkillMetadata, failures *[]LoadFailure) error {
 	entries, err := os.ReadDir(dir)
 	if err != nil {
 		return err
@@ -198,26 +300,75 @@ func (l *Loader) loadMetadataFromDir(ctx context.Context, dir string, source Ski
 		}
 
 		skillPath := filepath.Join(dir, entry.Name())
-		skillMDPath := filepath.Join(skillPath, SkillFileName)
-
-		fm, err := l.parser.ParseMetadataOnly(skillMDPath)
+		m, err := l.loadMetadata(skillPath, source)
 		if err != nil {
-			continue // Skip invalid skills silently for metadata loading
-		}
-
-		metadata[fm.Name] = SkillMetadata{
-			Name:        fm.Name,
-			Description: fm.Description,
-			Source:      source,
-			Path:        skillPath,
+			// Directories without SKILL.md are not skills
+			if failures != nil && !errors.Is(err, fs.ErrNotExist) {
+				*failures = append(*failures, LoadFailure{Path: skillPath, Source: source, Err: err})
+				l.logger.Warn("invalid skill",
+					slog.String(logKeyPath, skillPath),
+					slog.String(logKeySource, string(source)),
+					slog.Any("error", err))
+			}
+			continue
 		}
+		metadata[m.Ref()] = m
 	}
 
 	return nil
 }
 
-// loadSingleSkill loads a single skill from a directory.
+// loadMetadata parses the frontmatter of a single skill directory.
+func (l *Loader) loadMetadata(skillPath string, source SkillSource) (SkillMetadata, error) {
+	skillMDPath := filepath.Join(skillPath, SkillFileName)
+	fm, err := l.parser.ParseMetadataOnly(skillMDPath)
+	if err != nil {
+		return SkillMetadata{}, err
+	}
+	info, err := os.Stat(skillMDPath)
+	if err != nil {
+		return SkillMetadata{}, err
+	}
+
+	m := SkillMetadata{
+		Name:        fm.Name,
+		Description: fm.Description,
+		Version:     skillVersion(fm, filepath.Base(skillPath)),
+		Tags:        fm.Tags,
+		Triggers:    fm.Triggers,
+		DependsOn:   fm.DependsOn,
+		Extends:     fm.Extends,
+		Deprecated:  fm.Deprecated,
+		ReplacedBy:  fm.ReplacedBy,
+		Source:      source,
+		Path:        skillPath,
+		modTime:     info.ModTime(),
+	}
+
+	cfg := l.config.Load()
+	if !cfg.Allows(m) {
+		return SkillMetadata{}, errSkillDisabled
+	}
+	return cfg.apply(m), nil
+}
+
+// sourceOf reports which configured skills directory a skill directory
+// belongs to. The skill directory must be a direct child of the root.
+func (l *Loader) sourceOf(skillPath string) (SkillSource, bool) {
+	parent := filepath.Dir(filepath.Clean(skillPath))
+	switch {
+	case samePath(parent, l.projectDir):
+		return SourceProject, true
+	case samePath(parent, l.globalDir):
+		return SourceGlobal, true
+	}
+	return "", false
+}
+
+// loadSingleSkill loads a single skill from a directory, applying the
+// config's overrides like loadMetadata.
 func (l *Loader) loadSingleSkill(ctx context.Context, skillPath string, source SkillSource) (*Skill, error) {
+	start := time.Now()
 	skillMDPath := filepath.Join(skillPath, SkillFileName)
 
 	// Check if SKILL.md exists
@@ -238,18 +389,44 @@ func (l *Loader) loadSingleSkill(ctx context.Context, skillPath string, source S
 	}
 
 	skill := &Skill{
-		Name:        fm.Name,
-		Description: fm.Description,
-		Path:        skillPath,
-		Content:     content,
-		Files:       files,
-		Source:      source,
-		LoadedAt:    time.Now(),
-	}
+		Name:         fm.Name,
+		Description:  fm.Description,
+		Version:      skillVersion(fm, filepath.Base(skillPath)),
+		Tags:         fm.Tags,
+		Triggers:     fm.Triggers,
+		DependsOn:    fm.DependsOn,
+		Extends:      fm.Extends,
+		Deprecated:   fm.Deprecated,
+		ReplacedBy:   fm.ReplacedBy,
+		AllowedTools: fm.AllowedTools,
+		Author:       fm.Author,
+		License:      fm.License,
+		Path:         skillPath,
+		Content:      content,
+		Files:        files,
+		Source:       source,
+		LoadedAt:     time.Now(),
+	}
+	skill.Description = l.config.Load().apply(skill.ToMetadata()).Description
+
+	l.logger.Debug("loaded skill",
+		slog.String(logKeySkill, skill.Name),
+		slog.String(logKeyPath, skillPath),
+		slog.String(logKeySource, string(source)),
+		slog.Duration(logKeyDuration, time.Since(start)))
 
 	return skill, nil
 }
 
+// skillVersion returns the frontmatter version, falling back to the
+// "@version" suffix of the skill directory name.
+func skillVersion(fm *Frontmatter, dirName string) string {
+	if fm.Version != "" {
+		return fm.Version
+	}
+	return dirVersion(dirName)
+}
+
 // discoverFiles finds all bundled files in a skill directory.
 func (l *Loader) discoverFiles(skillPath string) ([]SkillFile, error) {
 	var files []SkillFile
@@ -318,6 +495,17 @@ func expandPath(path string) string {
 	return path
 }
 
+// samePath reports whether two paths refer to the same location,
+// comparing their absolute, cleaned forms.
+func samePath(a, b string) bool {
+	absA, errA := filepath.Abs(a)
+	absB, errB := filepath.Abs(b)
+	if errA != nil || errB != nil {
+		return filepath.Clean(a) == filepath.Clean(b)
+	}
+	return absA == absB
+}
+
 // ListSkills returns a formatted list of available skills.
 func (l *Loader) ListSkills(ctx context.Context) (string, error) {
 	metadata, err := l.LoadMetadataOnly(ctx)
@@ -333,17 +521,21 @@ func (l *Loader) ListSkills(ctx context.Context) (string, error) {
 	sb.WriteString("Available Skills:\n\n")
 
 	for _, m := range metadata {
-		sb.WriteString(fmt.Sprintf("• %s (%s)\n", m.Name, m.Source))
+		sb.WriteString(fmt.Sprintf("• %s (%s)\n", m.Ref(), m.Source))
 		sb.WriteString(fmt.Sprintf("  %s\n\n", truncate(m.Description, 100)))
 	}
 
 	return sb.String(), nil
 }
 
-// truncate shortens a string to maxLen characters.
+// truncate shortens a string to maxLen bytes without splitting a UTF-8 character.
 func truncate(s string, maxLen int) string {
 	if len(s) <= maxLen {
 		return s
 	}
-	return s[:maxLen-3] + "..."
+	cut := maxLen - 3
+	for cut > 0 && !utf8.RuneStart(s[cut]) {
+		cut--
+	}
+	return s[:cut] + "..."
 }
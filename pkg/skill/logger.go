@@ -0,0 +1,72 @@
+package skill
+
+import (
+	"context"
+	"log/slog"
+)
+
+// Structured log attribute keys used across the package.
+const (
+	logKeySkill    = "skill"
+	logKeyPath     = "path"
+	logKeySource   = "source"
+	logKeyDuration = "duration"
+)
+
+// discardLogger is the default logger: the library is silent unless the
+// embedding application passes a logger.
+var discardLogger = slog.New(slog.DiscardHandler)
+
+// WithLoaderLogger sets the logger for skill discovery and parsing.
+// Default: discard
+func WithLoaderLogger(logger *slog.Logger) LoaderOption {
+	return func(l *Loader) {
+		if logger != nil {
+			l.logger = logger
+		}
+	}
+}
+
+// WithRegistryLogger sets the logger for reloads and lookups.
+// Default: the loader's logger
+func WithRegistryLogger(logger *slog.Logger) RegistryOption {
+	return func(r *Registry) {
+		if logger != nil {
+			r.logger = logger
+		}
+	}
+}
+
+// WithWatcherLogger sets the logger for file watching.
+// Default: the registry's logger
+func WithWatcherLogger(logger *slog.Logger) WatcherOption {
+	return func(w *Watcher) {
+		if logger != nil {
+			w.logger = logger
+		}
+	}
+}
+
+// logEvent logs a registry event: changes at info level, problems at warn.
+func (r *Registry) logEvent(e Event) {
+	attrs := []slog.Attr{slog.String("event", string(e.Type))}
+	if e.Name != "" {
+		attrs = append(attrs, slog.String(logKeySkill, e.Name))
+	}
+	if e.Metadata.Path != "" {
+		attrs = append(attrs,
+			slog.String(logKeyPath, e.Metadata.Path),
+			slog.String(logKeySource, string(e.Metadata.Source)))
+	}
+
+	switch e.Type {
+	case EventReloadFailed, EventSkillInvalid:
+		attrs = append(attrs, slog.Any("error", e.Err))
+		r.logger.LogAttrs(context.Background(), slog.LevelWarn, "skill load problem", attrs...)
+	default:
+		if e.OldVersion != "" || e.NewVersion != "" {
+			attrs = append(attrs, slog.String("old_version", e.OldVersion), slog.String("new_version", e.NewVersion))
+		}
+		r.logger.LogAttrs(context.Background(), slog.LevelInfo, "skill changed", attrs...)
+	}
+}
@@ -0,0 +1,71 @@
+package skill
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestRegistryLogging(t *testing.T) {
+	dir := t.TempDir()
+	writeTestSkill(t, filepath.Join(dir, "deploy"), "deploy", "Deploy services", "")
+	if err := os.MkdirAll(filepath.Join(dir, "broken"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "broken", SkillFileName), []byte("no frontmatter\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	loader := NewLoader(WithGlobalSkillsDir(filepath.Join(dir, "missing")), WithProjectSkillsDir(dir), WithLoaderLogger(logger))
+	registry := NewRegistry(loader)
+	if err := registry.Initialize(context.Background()); err != nil {
+		t.Fatalf("Initialize() error: %v", err)
+	}
+
+	records := make(map[string]map[string]any)
+	for _, line := range bytes.Split(bytes.TrimSpace(buf.Bytes()), []byte("\n")) {
+		var record map[string]any
+		if err := json.Unmarshal(line, &record); err != nil {
+			t.Fatalf("invalid log line %q: %v", line, err)
+		}
+		records[record["msg"].(string)+"/"+stringAttr(record, "event")] = record
+	}
+
+	tests := []struct {
+		key   string
+		attrs map[string]string
+	}{
+		{key: "invalid skill/", attrs: map[string]string{"level": "WARN", logKeyPath: filepath.Join(dir, "broken"), logKeySource: string(SourceProject)}},
+		{key: "skill changed/" + string(EventSkillAdded), attrs: map[string]string{"level": "INFO", logKeySkill: "deploy", logKeySource: string(SourceProject)}},
+		{key: "skills loaded/", attrs: map[string]string{"level": "INFO"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			record, ok := records[tt.key]
+			if !ok {
+				t.Fatalf("no %q record in log:\n%s", tt.key, buf.String())
+			}
+			for k, v := range tt.attrs {
+				if got := stringAttr(record, k); got != v {
+					t.Errorf("%s = %q, want %q", k, got, v)
+				}
+			}
+		})
+	}
+
+	if _, ok := records["skills loaded/"][logKeyDuration]; !ok {
+		t.Error("skills loaded record has no duration")
+	}
+}
+
+func stringAttr(record map[string]any, key string) string {
+	s, _ := record[key].(string)
+	return s
+}
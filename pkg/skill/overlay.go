@@ -0,0 +1,282 @@
+package skill
+
+import (
+	"context"
+	"maps"
+	"sync"
+	"time"
+)
+
+// Overlay is a session-scoped layer over a shared registry. Skills added to
+// the overlay are visible only through it and shadow registry skills with
+// the same name; the registry itself is never modified. Overlay skills are
+// held in memory, while registry skills are still served from the shared
+// registry cache.
+type Overlay struct {
+	registry *Registry
+	id       string
+	usage    usageTracker
+
+	mu       sync.Mutex
+	skills   map[string]*Skill // by name
+	revision uint64
+
+	// current merges the overlay into the registry snapshot it was built from
+	current         *Snapshot
+	currentRevision uint64
+}
+
+// Overlay returns the overlay of a session, creating an empty one on first use.
+func (r *Registry) Overlay(sessionID string) *Overlay {
+	r.sessionsMu.Lock()
+	defer r.sessionsMu.Unlock()
+
+	if o, ok := r.sessions[sessionID]; ok {
+		return o
+	}
+	if r.sessions == nil {
+		r.sessions = make(map[string]*Overlay)
+	}
+	o := &Overlay{registry: r, id: sessionID, skills: make(map[string]*Skill)}
+	r.sessions[sessionID] = o
+	return o
+}
+
+// EndSession discards the overlay of a session. Existing references to the
+// overlay keep working, but Overlay returns a new, empty one afterwards.
+func (r *Registry) EndSession(sessionID string) {
+	r.sessionsMu.Lock()
+	defer r.sessionsMu.Unlock()
+	delete(r.sessions, sessionID)
+}
+
+// ID returns the session ID of the overlay.
+func (o *Overlay) ID() string {
+	return o.id
+}
+
+// Add adds a skill to the session, replacing a session skill with the same
+// name. The skill is copied and marked as SourceSession.
+func (o *Overlay) Add(skill *Skill) error {
+	_, err := o.add(skill)
+	return err
+}
+
+// add implements Add and returns the stored copy.
+func (o *Overlay) add(skill *Skill) (*Skill, error) {
+	fm := Frontmatter{Name: skill.Name, Description: skill.Description}
+	if err := fm.Validate(); err != nil {
+		return nil, err
+	}
+
+	s := *skill
+	s.Source = SourceSession
+	if s.LoadedAt.IsZero() {
+		s.LoadedAt = time.Now()
+	}
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.skills[s.Name] = &s
+	o.revision++
+	return &s, nil
+}
+
+// AddContent parses an uploaded SKILL.md and adds it to the session.
+// The skill has no directory, so it has no bundled files.
+func (o *Overlay) AddContent(data []byte) (*Skill, error) {
+	fm, body, err := o.registry.loader.parser.Parse(data)
+	if err != nil {
+		return nil, err
+	}
+
+	skill := &Skill{
+		Name:         fm.Name,
+		Description:  fm.Description,
+		Version:      fm.Version,
+		Tags:         fm.Tags,
+		Triggers:     fm.Triggers,
+		DependsOn:    fm.DependsOn,
+		Extends:      fm.Extends,
+		Deprecated:   fm.Deprecated,
+		ReplacedBy:   fm.ReplacedBy,
+		AllowedTools: fm.AllowedTools,
+		Author:       fm.Author,
+		License:      fm.License,
+		Content:      body,
+	}
+	return o.add(skill)
+}
+
+// AddDir loads a draft skill directory, including its bundled files, and
+// adds it to the session.
+func (o *Overlay) AddDir(ctx context.Context, dir string) (*Skill, error) {
+	skill, err := o.registry.loader.LoadSkillFromDir(ctx, dir, SourceSession)
+	if err != nil {
+		return nil, err
+	}
+	return o.add(skill)
+}
+
+// Remove removes a session skill, uncovering a registry skill it shadowed.
+// It reports whether the skill was in the session.
+func (o *Overlay) Remove(name string) bool {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if _, ok := o.skills[name]; !ok {
+		return false
+	}
+	delete(o.skills, name)
+	o.revision++
+	return true
+}
+
+// Snapshot returns the registry's current snapshot with the session skills
+// merged in.
+func (o *Overlay) Snapshot() *Snapshot {
+	base := o.registry.Snapshot()
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if o.current == nil || o.current.generation != base.generation || o.currentRevision != o.revision {
+		o.current = base.withSkills(o.skills)
+		o.current.usage = &o.usage
+		o.currentRevision = o.revision
+		o.usage.retain(o.current.versions)
+	}
+	return o.current
+}
+
+// withSkills returns a snapshot in which the given in-memory skills replace
+// all versions of the skills with the same names.
+func (s *Snapshot) withSkills(skills map[string]*Skill) *Snapshot {
+	merged := &Snapshot{
+		registry:   s.registry,
+		generation: s.generation,
+		loadedAt:   s.loadedAt,
+		versions:   make(map[string][]SkillMetadata, len(s.versions)+len(skills)),
+		failures:   s.failures,
+		vectors:    s.vectors,
+		filtered:   s.filtered,
+		unfiltered: s.unfiltered,
+		local:      maps.Clone(s.local),
+		usage:      s.usage,
+	}
+	if merged.local == nil {
+		merged.local = make(map[string]*Skill, len(skills))
+	}
+
+	for name, versions := range s.versions {
+		if _, ok := skills[name]; !ok {
+			merged.versions[name] = versions
+		}
+	}
+	for _, m := range s.metadata {
+		if _, ok := skills[m.Name]; !ok {
+			merged.metadata = append(merged.metadata, m)
+		}
+	}
+
+	// Shadowed skills must not keep their embeddings
+	if s.vectors != nil {
+		merged.vectors = make(map[string][]float64, len(s.vectors))
+		for _, m := range merged.metadata {
+			if v, ok := s.vectors[m.Ref()]; ok {
+				merged.vectors[m.Ref()] = v
+			}
+		}
+	}
+
+	for _, skill := range skills {
+		m := skill.ToMetadata()
+		merged.versions[m.Name] = []SkillMetadata{m}
+		merged.metadata = append(merged.metadata, m)
+		merged.local[m.Ref()] = skill
+	}
+	sortByName(merged.metadata)
+	merged.index = newSearchIndex(merged.metadata)
+
+	return merged
+}
+
+// Resolve returns the metadata of the session or registry skill a reference points to.
+func (o *Overlay) Resolve(ref string) (SkillMetadata, error) {
+	return o.Snapshot().Resolve(ref)
+}
+
+// Versions returns all visible versions of a skill, newest first.
+func (o *Overlay) Versions(name string) []SkillMetadata {
+	return o.Snapshot().Versions(name)
+}
+
+// Get retrieves a session or registry skill.
+func (o *Overlay) Get(ctx context.Context, name string) (*Skill, error) {
+	return o.Snapshot().Get(ctx, name)
+}
+
+// GetContent retrieves the full content of a session or registry skill.
+func (o *Overlay) GetContent(ctx context.Context, name string) (string, error) {
+	return o.Snapshot().GetContent(ctx, name)
+}
+
+// GetMetadata returns a copy of the metadata of all visible skills.
+func (o *Overlay) GetMetadata() []SkillMetadata {
+	return o.Snapshot().GetMetadata()
+}
+
+// FindMatchingSkill finds a visible skill that matches the given query.
+func (o *Overlay) FindMatchingSkill(query string) *SkillMetadata {
+	return o.Snapshot().FindMatchingSkill(query)
+}
+
+// FindMatchingSkills ranks visible skills against a query using BM25.
+func (o *Overlay) FindMatchingSkills(query string, k int) []SkillMatch {
+	return o.Snapshot().FindMatchingSkills(query, k)
+}
+
+// Search ranks visible skills against a natural-language query.
+func (o *Overlay) Search(ctx context.Context, query string, k int) ([]SkillMatch, error) {
+	return o.Snapshot().Search(ctx, query, k)
+}
+
+// SearchContent searches the bodies and reference files of visible skills.
+func (o *Overlay) SearchContent(ctx context.Context, query string, k int) ([]ContentHit, error) {
+	return o.Snapshot().SearchContent(ctx, query, k)
+}
+
+// GenerateSystemPromptSection generates the skills section for system prompts.
+func (o *Overlay) GenerateSystemPromptSection() string {
+	return o.Snapshot().GenerateSystemPromptSection()
+}
+
+// GenerateSkillsInstructions generates instructions for using skills.
+func (o *Overlay) GenerateSkillsInstructions() string {
+	return o.Snapshot().GenerateSkillsInstructions()
+}
+
+// RenderPrompt renders the prompt for the session and registry skills.
+func (o *Overlay) RenderPrompt(tools []string) Prompt {
+	return o.Snapshot().RenderPrompt(tools)
+}
+
+// Dependencies returns the dependency graph of the visible skills.
+func (o *Overlay) Dependencies() *DependencyGraph {
+	return o.Snapshot().Dependencies()
+}
+
+// Prerequisites returns the visible skills a skill transitively depends on.
+func (o *Overlay) Prerequisites(ref string) ([]SkillMetadata, error) {
+	return o.Snapshot().Prerequisites(ref)
+}
+
+// Count returns the number of visible skills.
+func (o *Overlay) Count() int {
+	return o.Snapshot().Count()
+}
+
+// Names returns all visible skill names.
+func (o *Overlay) Names() []string {
+	return o.Snapshot().Names()
+}
@@ -0,0 +1,101 @@
+package skill
+
+import (
+	"context"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestRegistryOverlay(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	writeTestSkill(t, filepath.Join(dir, "deploy"), "deploy", "Deploy services", "1.0.0")
+	writeTestSkill(t, filepath.Join(dir, "lint"), "lint", "Lint code", "")
+
+	registry := NewRegistry(NewLoader(WithGlobalSkillsDir(filepath.Join(dir, "missing")), WithProjectSkillsDir(dir)))
+	if err := registry.Initialize(ctx); err != nil {
+		t.Fatalf("Initialize() error: %v", err)
+	}
+	baseDeploy, err := registry.Get(ctx, "deploy")
+	if err != nil {
+		t.Fatalf("Get(deploy) error: %v", err)
+	}
+
+	session := registry.Overlay("alice")
+	if registry.Overlay("alice") != session {
+		t.Fatal("Overlay() returned a new overlay for an existing session")
+	}
+	if _, err := session.AddContent([]byte("---\nname: deploy\ndescription: Alice's deploy draft\n---\n\n# deploy\n\nUse the staging cluster.\n")); err != nil {
+		t.Fatalf("AddContent() error: %v", err)
+	}
+	draftDir := filepath.Join(t.TempDir(), "notes")
+	writeTestSkill(t, draftDir, "notes", "Take meeting notes", "")
+	if _, err := session.AddDir(ctx, draftDir); err != nil {
+		t.Fatalf("AddDir() error: %v", err)
+	}
+	if err := session.Add(&Skill{Name: "empty"}); err == nil {
+		t.Error("Add() accepted a skill without description")
+	}
+
+	tests := []struct {
+		name        string
+		catalog     Catalog
+		names       []string
+		description string
+		content     string
+	}{
+		{name: "session", catalog: session, names: []string{"deploy", "lint", "notes"}, description: "Alice's deploy draft", content: "staging cluster"},
+		{name: "other session", catalog: registry.Overlay("bob"), names: []string{"deploy", "lint"}, description: "Deploy services", content: "Do the thing"},
+		{name: "registry", catalog: registry, names: []string{"deploy", "lint"}, description: "Deploy services", content: "Do the thing"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			names := tt.catalog.Names()
+			slices.Sort(names)
+			if !slices.Equal(names, tt.names) {
+				t.Errorf("Names() = %v, want %v", names, tt.names)
+			}
+			m, err := tt.catalog.Resolve("deploy")
+			if err != nil || m.Description != tt.description {
+				t.Errorf("Resolve(deploy) = %q, %v, want %q", m.Description, err, tt.description)
+			}
+			content, err := tt.catalog.GetContent(ctx, "deploy")
+			if err != nil || !strings.Contains(content, tt.content) {
+				t.Errorf("GetContent(deploy) = %q, %v, want %q", content, err, tt.content)
+			}
+		})
+	}
+
+	if match := session.FindMatchingSkill("meeting notes"); match == nil || match.Name != "notes" {
+		t.Errorf("FindMatchingSkill(meeting notes) = %v, want notes", match)
+	}
+	if s, _ := registry.Get(ctx, "deploy"); s != baseDeploy {
+		t.Error("overlay changed the registry's cached skill")
+	}
+	if stats := registry.Stats(); stats.Entries != 1 {
+		t.Errorf("registry cache holds %d entries, want only the base deploy skill", stats.Entries)
+	}
+
+	// Removing a session skill uncovers the registry skill
+	if !session.Remove("deploy") {
+		t.Error("Remove(deploy) = false")
+	}
+	if m, _ := session.Resolve("deploy"); m.Source != SourceProject {
+		t.Errorf("Resolve(deploy) source = %s after Remove, want project", m.Source)
+	}
+
+	// Session skills survive registry reloads but not the end of the session
+	if err := registry.Reload(ctx); err != nil {
+		t.Fatalf("Reload() error: %v", err)
+	}
+	if !slices.Contains(session.Names(), "notes") {
+		t.Error("session skill lost after Reload")
+	}
+	registry.EndSession("alice")
+	if slices.Contains(registry.Overlay("alice").Names(), "notes") {
+		t.Error("session skill survived EndSession")
+	}
+}
@@ -56,11 +56,7 @@ func (p *Parser) ParseMetadataOnly(path string) (*Frontmatter, error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed to open file: %w", err)
 	}
-	defer func() {
-		if closeErr := file.Close(); closeErr != nil {
-			fmt.Fprintf(os.Stderr, "Warning: failed to close file %s: %v\n", path, closeErr)
-		}
-	}()
+	defer file.Close()
 
 	scanner := bufio.NewScanner(file)
 	var frontmatterLines []string
@@ -163,64 +159,177 @@ func (p *Parser) splitFrontmatter(data []byte) (frontmatter []byte, body string,
 }
 
 // ExtractSection extracts a specific markdown section by heading.
-// Useful for getting specific parts of skill instructions.
+// Useful for getting specific parts of skill instructions. Lines inside
+// fenced code blocks are never taken for headings, as in ExtractTOC.
 func (p *Parser) ExtractSection(body, heading string) string {
 	lines := strings.Split(body, "\n")
-	var result []string
-	inSection := false
-	sectionLevel := 0
-
-	for _, line := range lines {
-		// Check if this is a heading
-		if strings.HasPrefix(line, "#") {
-			level := countPrefix(line, '#')
-			headingText := strings.TrimSpace(strings.TrimLeft(line, "#"))
-
-			if strings.EqualFold(headingText, heading) {
-				inSection = true
-				sectionLevel = level
-				result = append(result, line)
-				continue
-			}
+	headings := scanHeadings(lines)
+
+	for i, h := range headings {
+		if strings.EqualFold(h.text, heading) {
+			end := sectionEnd(headings, i, len(lines))
+			return strings.TrimSpace(strings.Join(lines[h.line:end], "\n"))
+		}
+	}
+	return ""
+}
+
+// ExtractTOC extracts all markdown headings and returns a formatted table of contents.
+// Each heading is indented based on its level (H1 = no indent, H2 = 2 spaces, etc.).
+func (p *Parser) ExtractTOC(body string) string {
+	return p.ExtractTOCWithTokens(body, nil)
+}
+
+// ExtractTOCWithTokens works like ExtractTOC but annotates each heading with
+// the estimated token count of its section (including subsections), so a
+// caller can judge the cost of loading it. A nil counter disables annotations.
+func (p *Parser) ExtractTOCWithTokens(body string, counter TokenCounter) string {
+	lines := strings.Split(body, "\n")
+	headings := scanHeadings(lines)
+	toc := make([]string, 0, len(headings))
+
+	for i, h := range headings {
+		// Calculate indentation: (level-1) * 2 spaces
+		indent := strings.Repeat(" ", (h.level-1)*2)
+		entry := fmt.Sprintf("%s%s %s", indent, strings.Repeat("#", h.level), h.text)
+
+		if counter != nil {
+			end := sectionEnd(headings, i, len(lines))
+			tokens := counter.CountTokens(strings.Join(lines[h.line:end], "\n"))
+			entry += fmt.Sprintf(" (~%d tokens)", tokens)
+		}
+
+		toc = append(toc, entry)
+	}
+
+	return strings.Join(toc, "\n")
+}
+
+// TruncateSections returns the leading whole sections of body that fit in
+// maxTokens, along with the headings of the sections that were cut.
+// If not even the first section fits, it is cut at a line boundary.
+// A nil counter uses DefaultTokenCounter.
+func (p *Parser) TruncateSections(body string, maxTokens int, counter TokenCounter) (string, []string) {
+	if counter == nil {
+		counter = DefaultTokenCounter
+	}
+	if maxTokens <= 0 || counter.CountTokens(body) <= maxTokens {
+		return body, nil
+	}
+
+	lines := strings.Split(body, "\n")
+	headings := scanHeadings(lines)
+
+	// Chunk boundaries: document start plus every heading line
+	starts := []int{0}
+	for _, h := range headings {
+		if h.line > 0 {
+			starts = append(starts, h.line)
+		}
+	}
 
-			// End section if we hit same or higher level heading
-			if inSection && level <= sectionLevel {
+	var kept []string
+	used := 0
+	cut := len(starts)
+	for i, start := range starts {
+		end := len(lines)
+		if i+1 < len(starts) {
+			end = starts[i+1]
+		}
+		chunk := strings.Join(lines[start:end], "\n")
+		tokens := counter.CountTokens(chunk)
+		if used+tokens > maxTokens {
+			cut = i
+			break
+		}
+		kept = append(kept, chunk)
+		used += tokens
+	}
+
+	// Nothing fits whole: keep as many lines of the first chunk as possible
+	if len(kept) == 0 {
+		for _, line := range lines {
+			tokens := counter.CountTokens(line + "\n")
+			if used+tokens > maxTokens {
 				break
 			}
+			kept = append(kept, line)
+			used += tokens
 		}
+		cut = 0
+	}
 
-		if inSection {
-			result = append(result, line)
+	var omitted []string
+	for _, h := range headings {
+		if cut < len(starts) && h.line >= starts[cut] {
+			omitted = append(omitted, h.text)
 		}
 	}
 
-	return strings.TrimSpace(strings.Join(result, "\n"))
+	return strings.TrimSpace(strings.Join(kept, "\n")), omitted
 }
 
-// ExtractTOC extracts all markdown headings and returns a formatted table of contents.
-// Each heading is indented based on its level (H1 = no indent, H2 = 2 spaces, etc.).
-func (p *Parser) ExtractTOC(body string) string {
-	lines := strings.Split(body, "\n")
-	var toc []string
+// mdHeading is a heading line found in a markdown body.
+type mdHeading struct {
+	line  int
+	level int
+	text  string
+}
 
-	for _, line := range lines {
+// scanHeadings returns the markdown headings in lines, ignoring lines inside
+// fenced code blocks (where "#" usually starts a shell comment).
+func scanHeadings(lines []string) []mdHeading {
+	var headings []mdHeading
+	var fence string
+
+	for i, line := range lines {
 		trimmed := strings.TrimSpace(line)
-		if strings.HasPrefix(trimmed, "#") {
-			level := countPrefix(trimmed, '#')
-			headingText := strings.TrimSpace(strings.TrimLeft(trimmed, "#"))
 
-			// Skip empty headings
-			if headingText == "" {
-				continue
+		if marker := fenceMarker(trimmed); marker != "" {
+			if fence == "" {
+				fence = marker
+			} else if strings.HasPrefix(trimmed, fence) {
+				fence = ""
 			}
+			continue
+		}
+		if fence != "" || !strings.HasPrefix(trimmed, "#") {
+			continue
+		}
 
-			// Calculate indentation: (level-1) * 2 spaces
-			indent := strings.Repeat(" ", (level-1)*2)
-			toc = append(toc, fmt.Sprintf("%s%s %s", indent, strings.Repeat("#", level), headingText))
+		level := countPrefix(trimmed, '#')
+		headingText := strings.TrimSpace(strings.TrimLeft(trimmed, "#"))
+
+		// Skip empty headings
+		if headingText == "" {
+			continue
 		}
+
+		headings = append(headings, mdHeading{line: i, level: level, text: headingText})
 	}
 
-	return strings.Join(toc, "\n")
+	return headings
+}
+
+// sectionEnd returns the line index where the section opened by headings[i]
+// ends: the next heading of the same or a higher level, or total.
+func sectionEnd(headings []mdHeading, i, total int) int {
+	for _, next := range headings[i+1:] {
+		if next.level <= headings[i].level {
+			return next.line
+		}
+	}
+	return total
+}
+
+// fenceMarker returns the fence delimiter ("```" or "~~~") a line starts with.
+func fenceMarker(trimmed string) string {
+	for _, marker := range []string{"```", "~~~"} {
+		if strings.HasPrefix(trimmed, marker) {
+			return marker
+		}
+	}
+	return ""
 }
 
 // countPrefix counts how many times a character appears at the start of a string.
@@ -1,6 +1,7 @@
 package skill
 
 import (
+	"strings"
 	"testing"
 )
 
@@ -91,6 +92,12 @@ Just content.`,
 			expected: `# Title with spaces
   ## Another title`,
 		},
+		{
+			name: "comments inside code fences",
+			body: "# Build\n\n```bash\n# install dependencies\nnpm ci\n```\n\n~~~python\n## not a heading\n~~~\n\n## Test",
+			expected: `# Build
+  ## Test`,
+		},
 	}
 
 	for _, tt := range tests {
@@ -204,6 +211,12 @@ Last content.`,
 			expected: `# Last Section
 Last content.`,
 		},
+		{
+			name:     "comments inside code fences",
+			body:     "## Setup\nRun:\n\n```bash\n# install dependencies\nnpm ci\n```\n\nDone.\n\n## Usage\nUse it.",
+			heading:  "Setup",
+			expected: "## Setup\nRun:\n\n```bash\n# install dependencies\nnpm ci\n```\n\nDone.",
+		},
 	}
 
 	for _, tt := range tests {
@@ -215,3 +228,85 @@ Last content.`,
 		})
 	}
 }
+
+func TestExtractTOCWithTokens(t *testing.T) {
+	parser := NewParser()
+	words := TokenCounterFunc(func(text string) int {
+		return len(strings.Fields(text))
+	})
+
+	body := `# Guide
+Intro text here.
+
+## Setup
+one two three
+
+` + "```bash" + `
+# not a heading
+make build
+` + "```" + `
+
+## Usage
+four five`
+
+	expected := `# Guide (~22 tokens)
+  ## Setup (~13 tokens)
+  ## Usage (~4 tokens)`
+
+	if result := parser.ExtractTOCWithTokens(body, words); result != expected {
+		t.Errorf("ExtractTOCWithTokens() = %q, want %q", result, expected)
+	}
+}
+
+func TestTruncateSections(t *testing.T) {
+	parser := NewParser()
+	words := TokenCounterFunc(func(text string) int {
+		return len(strings.Fields(text))
+	})
+
+	body := `# Intro
+a b c
+
+## First
+d e f
+
+## Second
+g h i`
+
+	tests := []struct {
+		name      string
+		maxTokens int
+		expected  string
+		omitted   []string
+	}{
+		{
+			name:      "fits entirely",
+			maxTokens: 100,
+			expected:  body,
+		},
+		{
+			name:      "keeps whole sections",
+			maxTokens: 11,
+			expected:  "# Intro\na b c\n\n## First\nd e f",
+			omitted:   []string{"Second"},
+		},
+		{
+			name:      "first section too large",
+			maxTokens: 3,
+			expected:  "# Intro",
+			omitted:   []string{"Intro", "First", "Second"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, omitted := parser.TruncateSections(body, tt.maxTokens, words)
+			if result != tt.expected {
+				t.Errorf("TruncateSections() = %q, want %q", result, tt.expected)
+			}
+			if strings.Join(omitted, "|") != strings.Join(tt.omitted, "|") {
+				t.Errorf("TruncateSections() omitted = %v, want %v", omitted, tt.omitted)
+			}
+		})
+	}
+}
@@ -0,0 +1,111 @@
+package skill
+
+import (
+	"crypto/sha256"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// fileState is what the polling watcher records per path.
+type fileState struct {
+	modTime time.Time
+	size    int64
+	dir     bool
+	// hash is the SKILL.md content hash, used to catch rewrites that keep
+	// the size and mtime on filesystems with coarse timestamps
+	hash [sha256.Size]byte
+}
+
+// scan records the state of every file and directory below the roots and
+// of the watched files. Missing roots and files are skipped.
+func (w *Watcher) scan() map[string]fileState {
+	snapshot := make(map[string]fileState)
+	for _, file := range w.files {
+		if info, err := os.Stat(file); err == nil {
+			snapshot[file] = fileState{modTime: info.ModTime(), size: info.Size(), hash: hashFile(file)}
+		}
+	}
+	for _, root := range w.dirs {
+		_ = filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return nil // Skip unreadable entries; missing roots yield nothing
+			}
+			info, err := d.Info()
+			if err != nil {
+				return nil
+			}
+
+			state := fileState{modTime: info.ModTime(), size: info.Size(), dir: d.IsDir()}
+			if d.Name() == SkillFileName && !d.IsDir() {
+				state.hash = hashFile(path)
+			}
+			snapshot[path] = state
+			return nil
+		})
+	}
+	return snapshot
+}
+
+// poll compares a fresh scan with the previous one and returns the skill
+// directories that changed, sorted. rescan is set when a root appeared or
+// disappeared or a watched file changed.
+func (w *Watcher) poll() (dirs []string, rescan bool) {
+	current := w.scan()
+	previous := w.snapshot
+	w.snapshot = current
+
+	changed := make(map[string]bool)
+	mark := func(path string) {
+		if w.isRoot(path) || w.isWatchedFile(path) {
+			rescan = true
+			return
+		}
+		if dir, ok := w.skillDirFor(path); ok {
+			changed[dir] = true
+		}
+	}
+
+	for path, state := range current {
+		old, ok := previous[path]
+		if ok && w.isRoot(path) {
+			// Root mtimes change whenever a skill is added or removed;
+			// the skill directory itself reports that change
+			continue
+		}
+		if !ok || old != state {
+			mark(path)
+		}
+	}
+	for path := range previous {
+		if _, ok := current[path]; !ok {
+			mark(path)
+		}
+	}
+
+	for dir := range changed {
+		dirs = append(dirs, dir)
+	}
+	sort.Strings(dirs)
+	return dirs, rescan
+}
+
+// hashFile returns the SHA-256 of a file, or the zero hash if it cannot be read.
+func hashFile(path string) [sha256.Size]byte {
+	var sum [sha256.Size]byte
+	f, err := os.Open(path)
+	if err != nil {
+		return sum
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return sum
+	}
+	copy(sum[:], h.Sum(nil))
+	return sum
+}
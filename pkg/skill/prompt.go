@@ -0,0 +1,245 @@
+package skill
+
+import (
+	"log/slog"
+	"slices"
+	"sync"
+	"time"
+)
+
+// PromptOptions controls the skills section of the system prompt.
+//
+// Skills are always listed in name order, so the section is byte-identical
+// across runs and reloads as long as the skills do not change. When the
+// section exceeds MaxTokens, skills are chosen by priority: pinned skills,
+// then skills with a priority tag, then skills recently retrieved through
+// the same registry, view or overlay, then the rest by name. The skills
+// left out are summarized in a single line pointing to the list_skills
+// tool. The budget covers the skills section only, not the instructions.
+// Deprecated skills are not listed unless IncludeDeprecated is set.
+type PromptOptions struct {
+	// MaxTokens bounds the section size; 0 means unlimited
+	MaxTokens int
+
+	// Counter estimates token counts; nil uses DefaultTokenCounter
+	Counter TokenCounter
+
+	// Pinned names skills picked before other skills when a token budget
+	// applies; they are still dropped if they alone exceed it
+	Pinned []string
+
+	// Tags gives priority to skills with one of the tags (globs allowed)
+	Tags []string
+
+	// IgnoreRecent disables the priority of recently used skills
+	IgnoreRecent bool
+
+	// IncludeDeprecated lists deprecated skills too
+	IncludeDeprecated bool
+
+	// Renderer formats the prompt; nil uses XMLRenderer
+	Renderer PromptRenderer
+
+	// Tools are the tool names the instructions refer to; nil uses
+	// DefaultPromptTools. The middleware passes the tools it registers.
+	Tools []string
+}
+
+// WithPromptOptions configures the skills section of the system prompt.
+// Default: all skills except deprecated ones, no token budget
+func WithPromptOptions(opts PromptOptions) RegistryOption {
+	return func(r *Registry) {
+		r.prompt = opts
+	}
+}
+
+// usageTracker records when skills were last retrieved. The registry, each
+// view and each overlay keep their own, so one agent's lookups do not
+// reorder the prompt of another.
+type usageTracker struct {
+	mu       sync.Mutex
+	lastUsed map[string]time.Time
+}
+
+// touch records a use of the named skill.
+func (u *usageTracker) touch(name string) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	if u.lastUsed == nil {
+		u.lastUsed = make(map[string]time.Time)
+	}
+	u.lastUsed[name] = time.Now()
+}
+
+// snapshot returns a copy of the last use times.
+func (u *usageTracker) snapshot() map[string]time.Time {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	used := make(map[string]time.Time, len(u.lastUsed))
+	for name, t := range u.lastUsed {
+		used[name] = t
+	}
+	return used
+}
+
+// retain forgets skills that are no longer installed.
+func (u *usageTracker) retain(versions map[string][]SkillMetadata) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	for name := range u.lastUsed {
+		if _, ok := versions[name]; !ok {
+			delete(u.lastUsed, name)
+		}
+	}
+}
+
+// Prompt is a rendered skills prompt.
+type Prompt struct {
+	// Skills is the list of available skills, "" when there are none
+	Skills string
+
+	// Instructions explains how to use skills with the available tools
+	Instructions string
+}
+
+// RenderPrompt renders the skills section and the usage instructions for a
+// model that can call the given tools. A nil tools uses PromptOptions.Tools.
+func (s *Snapshot) RenderPrompt(tools []string) Prompt {
+	opts := s.registry.prompt
+	if tools == nil {
+		tools = opts.Tools
+	}
+	if tools == nil {
+		tools = DefaultPromptTools
+	}
+	renderer := opts.Renderer
+	if renderer == nil {
+		renderer = XMLRenderer{}
+	}
+
+	skills := s.metadata
+	if !opts.IncludeDeprecated {
+		skills = slices.DeleteFunc(slices.Clone(skills), func(m SkillMetadata) bool { return m.Deprecated })
+	}
+
+	var prompt Prompt
+	data := PromptData{Tools: tools}
+	if len(skills) > 0 {
+		data = s.promptData(opts, renderer, tools, skills)
+		prompt.Skills = s.render(renderer.RenderSkills, XMLRenderer{}.RenderSkills, data)
+	}
+	prompt.Instructions = s.render(renderer.RenderInstructions, XMLRenderer{}.RenderInstructions, data)
+	return prompt
+}
+
+// GenerateSystemPromptSection generates the skills section for system
+// prompts, applying the registry's PromptOptions.
+func (s *Snapshot) GenerateSystemPromptSection() string {
+	return s.RenderPrompt(nil).Skills
+}
+
+// GenerateSkillsInstructions generates instructions for using skills.
+func (s *Snapshot) GenerateSkillsInstructions() string {
+	return s.RenderPrompt(nil).Instructions
+}
+
+// render calls a renderer, falling back to the XML format if it fails.
+func (s *Snapshot) render(fn, fallback func(PromptData) (string, error), data PromptData) string {
+	out, err := fn(data)
+	if err != nil {
+		s.registry.logger.Warn("failed to render skills prompt", slog.Any("error", err))
+		out, _ = fallback(data)
+	}
+	return out
+}
+
+// promptData selects the skills to list within the token budget.
+func (s *Snapshot) promptData(opts PromptOptions, renderer PromptRenderer, tools []string, skills []SkillMetadata) PromptData {
+	data := PromptData{Skills: skills, Tools: tools}
+	if opts.MaxTokens <= 0 {
+		return data
+	}
+
+	counter := opts.Counter
+	if counter == nil {
+		counter = DefaultTokenCounter
+	}
+	tokens := func(d PromptData) int {
+		out, err := renderer.RenderSkills(d)
+		if err != nil {
+			out, _ = XMLRenderer{}.RenderSkills(d)
+		}
+		return counter.CountTokens(out)
+	}
+
+	// Estimate each skill's cost against the section without skills
+	base := tokens(PromptData{Omitted: len(skills), Tools: tools})
+	budget := opts.MaxTokens - base
+	listed := make([]bool, len(skills))
+	var picked []int
+	for _, i := range s.promptPriority(opts, skills) {
+		cost := tokens(PromptData{Skills: skills[i : i+1], Omitted: len(skills), Tools: tools}) - base
+		if cost > budget {
+			continue
+		}
+		budget -= cost
+		listed[i] = true
+		picked = append(picked, i)
+	}
+
+	build := func() PromptData {
+		d := PromptData{Tools: tools}
+		for i, m := range skills {
+			if listed[i] {
+				d.Skills = append(d.Skills, m)
+			}
+		}
+		d.Omitted = len(skills) - len(d.Skills)
+		return d
+	}
+
+	// Estimates may be off by a few tokens; drop the lowest priority
+	// skills until the section fits
+	data = build()
+	for len(picked) > 0 && tokens(data) > opts.MaxTokens {
+		listed[picked[len(picked)-1]] = false
+		picked = picked[:len(picked)-1]
+		data = build()
+	}
+	return data
+}
+
+// promptPriority returns the indexes of skills in the order they are
+// picked for a budgeted prompt section.
+func (s *Snapshot) promptPriority(opts PromptOptions, skills []SkillMetadata) []int {
+	var used map[string]time.Time
+	if !opts.IgnoreRecent {
+		used = s.usage.snapshot()
+	}
+
+	tier := func(m SkillMetadata) int {
+		switch {
+		case slices.Contains(opts.Pinned, m.Name):
+			return 0
+		case len(opts.Tags) > 0 && matchAnyTag(opts.Tags, m.Tags):
+			return 1
+		case !used[m.Name].IsZero():
+			return 2
+		}
+		return 3
+	}
+
+	order := make([]int, len(skills))
+	for i := range order {
+		order[i] = i
+	}
+	slices.SortStableFunc(order, func(a, b int) int {
+		ma, mb := skills[a], skills[b]
+		if ta, tb := tier(ma), tier(mb); ta != tb {
+			return ta - tb
+		}
+		// More recently used first; metadata is already in name order
+		return used[mb.Name].Compare(used[ma.Name])
+	})
+	return order
+}
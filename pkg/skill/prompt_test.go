@@ -0,0 +1,149 @@
+package skill
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestGenerateSystemPromptSectionStable(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	for _, name := range []string{"deploy", "lint", "review", "commit", "test", "docs"} {
+		writeTestSkill(t, filepath.Join(dir, name), name, "Skill "+name, "")
+	}
+
+	registry := NewRegistry(NewLoader(WithGlobalSkillsDir(filepath.Join(dir, "missing")), WithProjectSkillsDir(dir)))
+	if err := registry.Initialize(ctx); err != nil {
+		t.Fatalf("Initialize() error: %v", err)
+	}
+
+	first := registry.GenerateSystemPromptSection()
+	for range 5 {
+		if err := registry.Reload(ctx); err != nil {
+			t.Fatalf("Reload() error: %v", err)
+		}
+		if got := registry.GenerateSystemPromptSection(); got != first {
+			t.Fatalf("prompt section changed across reloads:\n%s\nvs\n%s", got, first)
+		}
+	}
+	if names := promptNames(first); !slices.IsSorted(names) {
+		t.Errorf("skills not in name order: %v", names)
+	}
+}
+
+func TestGenerateSystemPromptSectionBudget(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	names := []string{"alpha", "bravo", "delta", "gamma", "kappa", "sigma"}
+	for _, name := range names {
+		writeTestSkill(t, filepath.Join(dir, name), name, "Skill "+name, "")
+	}
+	if err := os.WriteFile(filepath.Join(dir, "kappa", SkillFileName),
+		[]byte("---\nname: kappa\ndescription: Skill kappa\ntags: [ops]\n---\n\n# kappa\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// Budget for exactly three skills and the summary line, with some slack
+	// below the cost of a fourth skill; all names have the same length
+	var three []SkillMetadata
+	for _, name := range names[:3] {
+		three = append(three, SkillMetadata{Name: name, Description: "Skill " + name, Path: filepath.Join(dir, name)})
+	}
+	counter := DefaultTokenCounter
+	section, _ := XMLRenderer{}.RenderSkills(PromptData{Skills: three, Omitted: 3, Tools: DefaultPromptTools})
+	budget := counter.CountTokens(section) + 5
+
+	tests := []struct {
+		name     string
+		opts     PromptOptions
+		used     []string
+		expected []string
+	}{
+		{name: "no budget", opts: PromptOptions{}, expected: names},
+		{name: "name order fills budget", opts: PromptOptions{MaxTokens: budget, IgnoreRecent: true}, expected: []string{"alpha", "bravo", "delta"}},
+		{name: "pinned first", opts: PromptOptions{MaxTokens: budget, Pinned: []string{"sigma"}, IgnoreRecent: true}, expected: []string{"alpha", "bravo", "sigma"}},
+		{name: "tag match", opts: PromptOptions{MaxTokens: budget, Tags: []string{"ops"}, IgnoreRecent: true}, expected: []string{"alpha", "bravo", "kappa"}},
+		{name: "recently used", opts: PromptOptions{MaxTokens: budget}, used: []string{"gamma", "delta"}, expected: []string{"alpha", "delta", "gamma"}},
+		{
+			name:     "priorities combine",
+			opts:     PromptOptions{MaxTokens: budget, Pinned: []string{"sigma"}, Tags: []string{"ops"}},
+			used:     []string{"gamma"},
+			expected: []string{"gamma", "kappa", "sigma"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			loader := NewLoader(WithGlobalSkillsDir(filepath.Join(dir, "missing")), WithProjectSkillsDir(dir))
+			registry := NewRegistry(loader, WithPromptOptions(tt.opts))
+			if err := registry.Initialize(ctx); err != nil {
+				t.Fatalf("Initialize() error: %v", err)
+			}
+			for _, name := range tt.used {
+				if _, err := registry.Get(ctx, name); err != nil {
+					t.Fatalf("Get(%s) error: %v", name, err)
+				}
+			}
+
+			section := registry.GenerateSystemPromptSection()
+			if got := promptNames(section); !slices.Equal(got, tt.expected) {
+				t.Errorf("listed %v, want %v", got, tt.expected)
+			}
+
+			more := fmt.Sprintf("%d more skills, use list_skills", len(names)-len(tt.expected))
+			if omitted := len(tt.expected) < len(names); strings.Contains(section, more) != omitted {
+				t.Errorf("summary line present = %v, want %v:\n%s", !omitted, omitted, section)
+			}
+			if tt.opts.MaxTokens > 0 && counter.CountTokens(section) > tt.opts.MaxTokens {
+				t.Errorf("section has %d tokens, budget %d", counter.CountTokens(section), tt.opts.MaxTokens)
+			}
+		})
+	}
+
+	// Uses are tracked per view and overlay, and content search is no use
+	loader := NewLoader(WithGlobalSkillsDir(filepath.Join(dir, "missing")), WithProjectSkillsDir(dir))
+	registry := NewRegistry(loader, WithPromptOptions(PromptOptions{MaxTokens: budget}))
+	if err := registry.Initialize(ctx); err != nil {
+		t.Fatalf("Initialize() error: %v", err)
+	}
+	if _, err := registry.SearchContent(ctx, "sigma", 0); err != nil {
+		t.Fatalf("SearchContent() error: %v", err)
+	}
+	view, overlay := registry.View(Filter{}), registry.Overlay("session")
+	if _, err := view.Get(ctx, "sigma"); err != nil {
+		t.Fatalf("View.Get() error: %v", err)
+	}
+	if _, err := overlay.Get(ctx, "kappa"); err != nil {
+		t.Fatalf("Overlay.Get() error: %v", err)
+	}
+	for _, c := range []struct {
+		name     string
+		catalog  Catalog
+		expected []string
+	}{
+		{"registry", registry, []string{"alpha", "bravo", "delta"}},
+		{"view", view, []string{"alpha", "bravo", "sigma"}},
+		{"overlay", overlay, []string{"alpha", "bravo", "kappa"}},
+	} {
+		if got := promptNames(c.catalog.GenerateSystemPromptSection()); !slices.Equal(got, c.expected) {
+			t.Errorf("%s listed %v, want %v", c.name, got, c.expected)
+		}
+	}
+}
+
+var promptNameRe = regexp.MustCompile(`<name>\n(.+)\n</name>`)
+
+// promptNames returns the skill names listed in a prompt section, in order.
+func promptNames(section string) []string {
+	var names []string
+	for _, m := range promptNameRe.FindAllStringSubmatch(section, -1) {
+		names = append(names, m[1])
+	}
+	return names
+}
@@ -0,0 +1,239 @@
+package skill
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// IssueSeverity classifies problems found while checking a skill.
+type IssueSeverity string
+
+const (
+	// SeverityError marks problems that break the skill at runtime
+	SeverityError IssueSeverity = "error"
+
+	// SeverityWarning marks problems that are likely mistakes
+	SeverityWarning IssueSeverity = "warning"
+)
+
+// ReferenceIssue is a broken link, missing bundled file or unrunnable script.
+type ReferenceIssue struct {
+	// Line is the 1-based line in SKILL.md, or 0 for file-level issues
+	Line int `json:"line,omitempty"`
+
+	// Target is the referenced path as written, or the bundled file path
+	Target string `json:"target"`
+
+	// Severity indicates whether the issue should fail validation
+	Severity IssueSeverity `json:"severity"`
+
+	// Message describes the problem
+	Message string `json:"message"`
+}
+
+func (i ReferenceIssue) String() string {
+	if i.Line > 0 {
+		return fmt.Sprintf("%s:%d: %s", SkillFileName, i.Line, i.Message)
+	}
+	return i.Message
+}
+
+var (
+	// markdownLinkPattern matches [text](target) and ![alt](target).
+	markdownLinkPattern = regexp.MustCompile(`!?\[[^\]]*\]\(\s*<?([^)\s>]+)>?(?:\s+["'][^)]*["'])?\s*\)`)
+
+	// bundledPathPattern matches bare paths into the bundled resource folders.
+	bundledPathPattern = regexp.MustCompile("(?:^|[\\s\"'`(=:,])((?:\\./)?(?:scripts|references|assets)/[A-Za-z0-9_.\\-/]*)")
+
+	// urlSchemePattern matches targets that carry a URL scheme (http:, mailto:, ...).
+	urlSchemePattern = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9+.\-]*:`)
+
+	// scriptInterpreters maps script extensions to the interpreter that runs them.
+	scriptInterpreters = map[string]string{
+		".sh":   "sh",
+		".bash": "bash",
+		".zsh":  "zsh",
+		".py":   "python3",
+		".js":   "node",
+		".mjs":  "node",
+		".cjs":  "node",
+		".ts":   "tsx",
+		".rb":   "ruby",
+		".pl":   "perl",
+		".php":  "php",
+		".ps1":  "pwsh",
+		".go":   "go run",
+		".lua":  "lua",
+	}
+)
+
+// ScriptInterpreter returns the interpreter for a script file: the shebang
+// command if present, otherwise one derived from the file extension.
+// It returns "" when the interpreter is unknown.
+func ScriptInterpreter(absPath string) string {
+	if data, err := readHead(absPath, 256); err == nil && bytes.HasPrefix(data, []byte("#!")) {
+		line, _, _ := bytes.Cut(data[2:], []byte("\n"))
+		return strings.TrimSpace(string(line))
+	}
+	return scriptInterpreters[strings.ToLower(filepath.Ext(absPath))]
+}
+
+// CheckReferences verifies that every relative markdown link and every
+// scripts/, references/ or assets/ path mentioned in SKILL.md resolves to a
+// bundled file, and that bundled scripts are executable or have a known
+// interpreter. Issues are reported with SKILL.md line numbers.
+func CheckReferences(s *Skill) ([]ReferenceIssue, error) {
+	data, err := os.ReadFile(s.SkillMDPath())
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+
+	files := make(map[string]bool, len(s.Files))
+	dirs := make(map[string]bool)
+	for _, f := range s.Files {
+		rel := filepath.ToSlash(f.RelPath)
+		files[rel] = true
+		for dir := path.Dir(rel); dir != "."; dir = path.Dir(dir) {
+			dirs[dir] = true
+		}
+	}
+
+	var issues []ReferenceIssue
+	seen := make(map[string]bool)
+	report := func(line int, target, message string) {
+		key := fmt.Sprintf("%d:%s", line, target)
+		if seen[key] {
+			return
+		}
+		seen[key] = true
+		issues = append(issues, ReferenceIssue{
+			Line:     line,
+			Target:   target,
+			Severity: SeverityError,
+			Message:  message,
+		})
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+		line := scanner.Text()
+
+		var linked []string
+		for _, m := range markdownLinkPattern.FindAllStringSubmatch(line, -1) {
+			target := m[1]
+			linked = append(linked, target)
+			if !isLocalLink(target) {
+				continue
+			}
+			if msg := resolveReference(s.Path, target, files, dirs); msg != "" {
+				report(lineNo, target, fmt.Sprintf("broken link %q: %s", target, msg))
+			}
+		}
+
+		for _, m := range bundledPathPattern.FindAllStringSubmatch(line, -1) {
+			target := strings.TrimRight(m[1], ".,:;")
+			if isPartOfLink(target, linked) || (strings.HasSuffix(target, "/") && strings.Count(target, "/") == 1) {
+				// Already checked as a link, or a bare folder mention such as "scripts/"
+				continue
+			}
+			if msg := resolveReference(s.Path, target, files, dirs); msg != "" {
+				report(lineNo, target, fmt.Sprintf("dead reference %q: %s", target, msg))
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("error reading file: %w", err)
+	}
+
+	for _, f := range s.Files {
+		if f.Type != FileTypeScript || strings.HasPrefix(filepath.Base(f.RelPath), ".") {
+			continue
+		}
+		info, err := os.Stat(f.AbsPath)
+		if err != nil {
+			continue
+		}
+		if info.Mode()&0111 == 0 && ScriptInterpreter(f.AbsPath) == "" {
+			issues = append(issues, ReferenceIssue{
+				Target:   f.RelPath,
+				Severity: SeverityWarning,
+				Message:  fmt.Sprintf("script %q is not executable and has no shebang or known interpreter", f.RelPath),
+			})
+		}
+	}
+
+	return issues, nil
+}
+
+// resolveReference checks a relative target against the bundled files and
+// returns a description of the problem, or "" if it resolves.
+func resolveReference(skillPath, target string, files, dirs map[string]bool) string {
+	// Drop fragment and query
+	if i := strings.IndexAny(target, "#?"); i >= 0 {
+		target = target[:i]
+	}
+	if target == "" {
+		return ""
+	}
+
+	rel := path.Clean(strings.TrimPrefix(target, "./"))
+	if rel == SkillFileName || rel == "." {
+		return ""
+	}
+
+	// Links leaving the skill directory are checked on disk
+	if rel == ".." || strings.HasPrefix(rel, "../") {
+		if _, err := os.Stat(filepath.Join(skillPath, filepath.FromSlash(rel))); err != nil {
+			return "path outside the skill directory does not exist"
+		}
+		return ""
+	}
+
+	if files[rel] || dirs[rel] {
+		return ""
+	}
+	return "file not found in skill bundle"
+}
+
+// isLocalLink reports whether a link target is a relative filesystem path.
+func isLocalLink(target string) bool {
+	return !strings.HasPrefix(target, "#") &&
+		!strings.HasPrefix(target, "/") &&
+		!strings.HasPrefix(target, "~") &&
+		!urlSchemePattern.MatchString(target)
+}
+
+// isPartOfLink reports whether a bare path was already checked as a link target.
+func isPartOfLink(target string, linked []string) bool {
+	for _, l := range linked {
+		if strings.Contains(l, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// readHead reads at most n bytes from the start of a file.
+func readHead(path string, n int) ([]byte, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	buf := make([]byte, n)
+	read, err := f.Read(buf)
+	if err != nil && read == 0 {
+		return nil, err
+	}
+	return buf[:read], nil
+}
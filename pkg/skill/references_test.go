@@ -0,0 +1,65 @@
+package skill
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestCheckReferences(t *testing.T) {
+	dir := t.TempDir()
+	skillMD := `---
+name: refs
+description: Skill with references
+---
+
+# Refs
+
+See [docs](references/guide.md) and [missing](references/missing.md#intro).
+Run ` + "`python3 scripts/run.py`" + ` then scripts/gone.sh.
+External [site](https://example.com) and [anchor](#refs) are ignored.
+Put outputs in assets/.
+`
+	files := map[string]string{
+		SkillFileName:         skillMD,
+		"references/guide.md": "# Guide\n",
+		"scripts/run.py":      "print('ok')\n",
+		"scripts/mystery":     "data\n",
+		"scripts/tool":        "#!/usr/bin/env bash\necho ok\n",
+		"assets/template.txt": "template\n",
+	}
+	for rel, content := range files {
+		p := filepath.Join(dir, rel)
+		if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	s, err := NewLoader().LoadSkillFromDir(context.Background(), dir, SourceProject)
+	if err != nil {
+		t.Fatalf("LoadSkillFromDir() error: %v", err)
+	}
+
+	issues, err := CheckReferences(s)
+	if err != nil {
+		t.Fatalf("CheckReferences() error: %v", err)
+	}
+
+	var got []string
+	for _, issue := range issues {
+		got = append(got, issue.String())
+	}
+	expected := []string{
+		`SKILL.md:8: broken link "references/missing.md#intro": file not found in skill bundle`,
+		`SKILL.md:9: dead reference "scripts/gone.sh": file not found in skill bundle`,
+		`script "scripts/mystery" is not executable and has no shebang or known interpreter`,
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("CheckReferences() = %q, want %q", got, expected)
+	}
+}
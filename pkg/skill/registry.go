@@ -2,19 +2,44 @@ package skill
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"strings"
+	"io/fs"
+	"log/slog"
+	"path/filepath"
+	"slices"
 	"sync"
+	"sync/atomic"
+	"time"
+
+	"golang.org/x/sync/singleflight"
 )
 
 // Registry manages loaded skills and provides lookup functionality.
+// Reads go through an immutable Snapshot that reloads replace atomically.
 type Registry struct {
-	mu        sync.RWMutex
-	skills    map[string]*Skill
-	metadata  []SkillMetadata
+	snapshot  atomic.Pointer[Snapshot]
+	cache     *skillCache
+	loads     singleflight.Group
+	pins      map[string]string
+	search    searchConfig
 	loader    *Loader
 	watcher   *Watcher
 	autoWatch bool
+	watchOpts []WatcherOption
+	logger    *slog.Logger
+	prompt    PromptOptions
+	usage     usageTracker
+
+	// reloadMu serializes full and incremental reloads
+	reloadMu sync.Mutex
+
+	subMu       sync.Mutex
+	subscribers []subscriber
+	nextSubID   int
+
+	sessionsMu sync.Mutex
+	sessions   map[string]*Overlay
 }
 
 // RegistryOption configures the Registry.
@@ -28,16 +53,49 @@ func WithAutoWatch(enabled bool) RegistryOption {
 	}
 }
 
+// WithWatcherOptions configures the watcher started by StartWatching,
+// e.g. WithWatchMode(WatchModePoll) for network filesystems.
+func WithWatcherOptions(opts ...WatcherOption) RegistryOption {
+	return func(r *Registry) {
+		r.watchOpts = append(r.watchOpts, opts...)
+	}
+}
+
+// WithVersionPins sets the default version for skills with several installed
+// versions. Keys are skill names, values are exact versions or semver ranges
+// (e.g. "1.4.0", "^1.2"). Unpinned skills default to their newest version.
+func WithVersionPins(pins map[string]string) RegistryOption {
+	return func(r *Registry) {
+		for name, constraint := range pins {
+			r.pins[name] = constraint
+		}
+	}
+}
+
 // NewRegistry creates a new skills registry.
 func NewRegistry(loader *Loader, opts ...RegistryOption) *Registry {
 	r := &Registry{
-		skills: make(map[string]*Skill),
+		cache:  newSkillCache(),
+		pins:   make(map[string]string),
+		search: defaultSearchConfig(),
 		loader: loader,
 	}
+	r.snapshot.Store(&Snapshot{
+		registry: r,
+		versions: make(map[string][]SkillMetadata),
+		index:    newSearchIndex(nil),
+		usage:    &r.usage,
+	})
 
 	for _, opt := range opts {
 		opt(r)
 	}
+	if r.logger == nil {
+		r.logger = discardLogger
+		if loader != nil {
+			r.logger = loader.logger
+		}
+	}
 
 	return r
 }
@@ -49,8 +107,10 @@ func (r *Registry) StartWatching(ctx context.Context) error {
 		return fmt.Errorf("watcher already started")
 	}
 
+	// Config edits enable or disable skills, so they trigger a full reload
 	dirs := []string{r.loader.globalDir, r.loader.projectDir}
-	watcher, err := NewWatcher(r, dirs)
+	opts := append([]WatcherOption{watchFiles(r.loader.ConfigFile())}, r.watchOpts...)
+	watcher, err := NewWatcher(r, dirs, opts...)
 	if err != nil {
 		return err
 	}
@@ -72,183 +132,313 @@ func (r *Registry) StopWatching() error {
 
 // Initialize loads all skills from configured directories.
 func (r *Registry) Initialize(ctx context.Context) error {
-	r.mu.Lock()
-
-	// Load metadata for system prompt
-	metadata, err := r.loader.LoadMetadataOnly(ctx)
+	r.reloadMu.Lock()
+	err := r.initialize(ctx)
+	r.reloadMu.Unlock()
 	if err != nil {
-		r.mu.Unlock()
-		return fmt.Errorf("failed to load skill metadata: %w", err)
+		return err
 	}
-	r.metadata = metadata
-
-	// Clear existing skills
-	r.skills = make(map[string]*Skill)
-
-	r.mu.Unlock()
 
 	// Start watching if autoWatch is enabled
 	if r.autoWatch && r.watcher == nil {
 		if err := r.StartWatching(ctx); err != nil {
 			// Log warning but don't fail initialization
-			fmt.Printf("Warning: failed to start auto-watch: %v\n", err)
+			r.logger.Warn("failed to start auto-watch", slog.Any("error", err))
 		}
 	}
 
 	return nil
 }
 
-// Get retrieves a skill by name, loading it on demand if needed.
-func (r *Registry) Get(ctx context.Context, name string) (*Skill, error) {
-	r.mu.RLock()
-	skill, exists := r.skills[name]
-	r.mu.RUnlock()
+// initialize rescans all skill directories. The caller holds reloadMu.
+func (r *Registry) initialize(ctx context.Context) error {
+	start := time.Now()
 
-	if exists {
-		return skill, nil
+	// Load metadata for system prompt
+	metadata, failures, err := r.loader.scanMetadata(ctx)
+	if err != nil {
+		err = fmt.Errorf("failed to load skill metadata: %w", err)
+		r.emit(Event{Type: EventReloadFailed, Err: err, Time: time.Now()})
+		return err
 	}
 
-	// Load on demand
-	skill, err := r.loader.LoadSkill(ctx, name)
-	if err != nil {
-		return nil, err
+	// Group versions by name, newest first
+	versions := make(map[string][]SkillMetadata)
+	for _, m := range metadata {
+		versions[m.Name] = append(versions[m.Name], m)
+	}
+	for _, vs := range versions {
+		sortByVersionDesc(vs)
+	}
+
+	// Keep serving skills whose SKILL.md was broken by an edit
+	var previous []SkillMetadata
+	for _, vs := range r.Snapshot().versions {
+		previous = append(previous, vs...)
 	}
+	failures = keepLastKnownGood(previous, versions, failures, make(map[string]bool))
 
-	r.mu.Lock()
-	r.skills[name] = skill
-	r.mu.Unlock()
+	r.apply(ctx, versions, nil, failures)
 
-	return skill, nil
+	r.logger.Info("skills loaded",
+		slog.Int("skills", len(versions)),
+		slog.Int("failures", len(failures)),
+		slog.Duration(logKeyDuration, time.Since(start)))
+	return nil
 }
 
-// GetContent retrieves the full content of a skill.
-func (r *Registry) GetContent(ctx context.Context, name string) (string, error) {
-	skill, err := r.Get(ctx, name)
-	if err != nil {
-		return "", err
+// ReloadSkill refreshes a single skill directory after it changed on disk:
+// its metadata is re-read and cached content for the skill is dropped.
+// If SKILL.md no longer loads, the last valid version is kept and marked
+// stale; the skill is removed only once its directory is deleted.
+// The directory must be a direct child of a configured skills directory.
+func (r *Registry) ReloadSkill(ctx context.Context, dir string) error {
+	dir = filepath.Clean(dir)
+	source, ok := r.loader.sourceOf(dir)
+	if !ok {
+		return &SkillError{SkillPath: dir, Message: "not in a configured skills directory"}
+	}
+	if err := ctx.Err(); err != nil {
+		return err
 	}
 
-	return r.loader.LoadSkillContent(ctx, skill)
-}
+	start := time.Now()
+	m, loadErr := r.loader.loadMetadata(dir, source)
 
-// GetMetadata returns all loaded skill metadata.
-func (r *Registry) GetMetadata() []SkillMetadata {
-	r.mu.RLock()
-	defer r.mu.RUnlock()
-	return r.metadata
-}
+	r.reloadMu.Lock()
+	defer r.reloadMu.Unlock()
 
-// FindMatchingSkill finds a skill that matches the given query.
-// This uses simple keyword matching for skill selection.
-func (r *Registry) FindMatchingSkill(query string) *SkillMetadata {
-	r.mu.RLock()
-	defer r.mu.RUnlock()
-
-	query = strings.ToLower(query)
-	var bestMatch *SkillMetadata
-	bestScore := 0
-
-	for i := range r.metadata {
-		m := &r.metadata[i]
-		score := r.calculateMatchScore(query, m)
-		if score > bestScore {
-			bestScore = score
-			bestMatch = m
+	current := r.Snapshot()
+	versions := make(map[string][]SkillMetadata, len(current.versions))
+	for name, vs := range current.versions {
+		versions[name] = append([]SkillMetadata(nil), vs...)
+	}
+	var failures []LoadFailure
+	for _, f := range current.failures {
+		if !samePath(f.Path, dir) {
+			failures = append(failures, f)
 		}
 	}
 
-	// Require minimum score to return a match
-	if bestScore < 2 {
-		return nil
+	changed := make(map[string]bool)
+
+	// Drop whatever was previously loaded from the directory
+	var removed []SkillMetadata
+	for name, vs := range versions {
+		kept := vs[:0]
+		for _, v := range vs {
+			if samePath(v.Path, dir) {
+				removed = append(removed, v)
+				changed[name] = true
+				continue
+			}
+			kept = append(kept, v)
+		}
+		versions[name] = kept
 	}
 
-	return bestMatch
+	var dirFailures []LoadFailure
+	switch {
+	case loadErr == nil:
+		addVersion(versions, m)
+		changed[m.Name] = true
+	case !errors.Is(loadErr, fs.ErrNotExist):
+		dirFailures = append(dirFailures, LoadFailure{Path: dir, Source: source, Err: loadErr})
+	}
+	dirFailures = keepLastKnownGood(removed, versions, dirFailures, changed)
+	failures = append(failures, dirFailures...)
+
+	// A removed project skill may have been shadowing a global one
+	for _, old := range removed {
+		if old.Source != SourceProject || slices.ContainsFunc(versions[old.Name], func(v SkillMetadata) bool {
+			return v.Ref() == old.Ref()
+		}) {
+			continue
+		}
+		global := make(map[string]SkillMetadata)
+		if err := r.loader.loadMetadataFromDir(ctx, r.loader.globalDir, SourceGlobal, global, nil); err == nil {
+			if g, ok := global[old.Ref()]; ok {
+				addVersion(versions, g)
+			}
+		}
+	}
+
+	for name := range changed {
+		if len(versions[name]) == 0 {
+			delete(versions, name)
+			continue
+		}
+		sortByVersionDesc(versions[name])
+	}
+
+	r.apply(ctx, versions, changed, failures)
+
+	r.logger.Info("skill reloaded",
+		slog.String(logKeyPath, dir),
+		slog.String(logKeySource, string(source)),
+		slog.Duration(logKeyDuration, time.Since(start)))
+	return nil
+}
+
+// addVersion inserts m into versions, replacing an entry with the same
+// reference unless that entry is a project skill shadowing a global one.
+func addVersion(versions map[string][]SkillMetadata, m SkillMetadata) {
+	vs := versions[m.Name]
+	for i, v := range vs {
+		if v.Ref() == m.Ref() {
+			if v.Source == SourceProject && m.Source == SourceGlobal {
+				return
+			}
+			vs[i] = m
+			return
+		}
+	}
+	versions[m.Name] = append(vs, m)
 }
 
-// calculateMatchScore computes how well a skill matches a query.
-func (r *Registry) calculateMatchScore(query string, m *SkillMetadata) int {
-	score := 0
-	queryWords := strings.Fields(query)
+// apply builds a snapshot from a new set of versions and load failures,
+// swaps it in and notifies subscribers. Cached skills are dropped for the
+// names in changed, or entirely when changed is nil, except for stale
+// skills, whose cached content is the last valid version.
+// The caller holds reloadMu.
+func (r *Registry) apply(ctx context.Context, versions map[string][]SkillMetadata, changed map[string]bool, failures []LoadFailure) {
+	defaults := make([]SkillMetadata, 0, len(versions))
+	for name, vs := range versions {
+		defaults = append(defaults, r.defaultVersion(name, vs))
+	}
+	sortByName(defaults)
+
+	// Embed descriptions before publishing the snapshot; a slow embedder
+	// delays other reloads, but lookups keep using the current snapshot
+	vectors, err := r.embedSkills(ctx, defaults)
+	if err != nil {
+		// Semantic search degrades to lexical matching
+		r.logger.Warn("failed to embed skills", slog.Any("error", err))
+	}
 
-	// Check skill name
-	name := strings.ToLower(m.Name)
-	for _, word := range queryWords {
-		if strings.Contains(name, word) {
-			score += 3
+	stale := make(map[string]bool)
+	for _, vs := range versions {
+		for _, v := range vs {
+			if v.Stale {
+				stale[v.Ref()] = true
+			}
 		}
 	}
 
-	// Check description
-	desc := strings.ToLower(m.Description)
-	for _, word := range queryWords {
-		if len(word) > 2 && strings.Contains(desc, word) {
-			score += 1
+	previous := r.Snapshot()
+	next := &Snapshot{
+		registry:   r,
+		generation: previous.generation + 1,
+		loadedAt:   time.Now(),
+		versions:   versions,
+		metadata:   defaults,
+		failures:   failures,
+		index:      newSearchIndex(defaults), // Full-text index used for skill matching
+		vectors:    vectors,
+		usage:      &r.usage,
+	}
+	r.usage.retain(versions)
+	events := diffMetadata(previous.metadata, defaults)
+	events = append(events, failureEvents(previous.failures, failures, versions)...)
+
+	// Invalidate cached skills before publishing the snapshot, so loads for
+	// older snapshots can no longer insert outdated entries
+	r.cache.sweep(next.generation, func(key string) (drop, pin bool) {
+		name, _ := SplitSkillRef(key)
+		return (changed == nil || changed[name]) && !stale[key], stale[key]
+	})
+	r.snapshot.Store(next)
+	next.logDependencies()
+
+	r.emit(events...)
+}
+
+// defaultVersion picks the version used for a bare skill name: the version
+// matching the pin, or the newest project version, or the newest global
+// version; see selectDefault. versions must be sorted newest first. Pins
+// are fixed at construction, so no lock is needed.
+func (r *Registry) defaultVersion(name string, versions []SkillMetadata) SkillMetadata {
+	if pin, ok := r.pins[name]; ok {
+		if m, ok := selectDefault(versions, pin); ok {
+			return m
 		}
 	}
+	m, _ := selectDefault(versions, "")
+	return m
+}
 
-	return score
+// Resolve returns the metadata of the installed version a reference points to.
+// The reference may be a bare name, "name@version" or "name@<semver range>".
+func (r *Registry) Resolve(ref string) (SkillMetadata, error) {
+	return r.Snapshot().Resolve(ref)
 }
 
-// GenerateSystemPromptSection generates the skills section for system prompts.
-func (r *Registry) GenerateSystemPromptSection() string {
-	r.mu.RLock()
-	defer r.mu.RUnlock()
+// Versions returns all installed versions of a skill, newest first.
+func (r *Registry) Versions(name string) []SkillMetadata {
+	return r.Snapshot().Versions(name)
+}
 
-	if len(r.metadata) == 0 {
-		return ""
-	}
+// Get retrieves a skill by name, loading it on demand if needed.
+// The name may select a version with "name@version" or "name@<semver range>";
+// a bare name resolves to the pinned or newest version.
+func (r *Registry) Get(ctx context.Context, name string) (*Skill, error) {
+	return r.Snapshot().Get(ctx, name)
+}
 
-	var sb strings.Builder
-	sb.WriteString("<available_skills>\n")
+// GetContent retrieves the full content of a skill.
+func (r *Registry) GetContent(ctx context.Context, name string) (string, error) {
+	return r.Snapshot().GetContent(ctx, name)
+}
 
-	for _, m := range r.metadata {
-		sb.WriteString("<skill>\n")
-		sb.WriteString(fmt.Sprintf("<name>\n%s\n</name>\n", m.Name))
-		sb.WriteString(fmt.Sprintf("<description>\n%s\n</description>\n", m.Description))
-		sb.WriteString(fmt.Sprintf("<location>\n%s/SKILL.md\n</location>\n", m.Path))
-		sb.WriteString("</skill>\n\n")
-	}
+// GetMetadata returns a copy of all loaded skill metadata.
+func (r *Registry) GetMetadata() []SkillMetadata {
+	return r.Snapshot().GetMetadata()
+}
 
-	sb.WriteString("</available_skills>\n")
+// FindMatchingSkill finds a skill that matches the given query.
+// It returns the best BM25 match, or nil if no skill matches strongly enough.
+func (r *Registry) FindMatchingSkill(query string) *SkillMetadata {
+	return r.Snapshot().FindMatchingSkill(query)
+}
 
-	return sb.String()
+// FindMatchingSkills ranks skills against a query using BM25 over names,
+// descriptions, tags and triggers, and returns the top k matches with
+// their scores. Queries may mix English and CJK text. A k <= 0 returns all
+// matches. Weak matches (a single description word) are not returned.
+func (r *Registry) FindMatchingSkills(query string, k int) []SkillMatch {
+	return r.Snapshot().FindMatchingSkills(query, k)
+}
+
+// GenerateSystemPromptSection generates the skills section for system prompts.
+func (r *Registry) GenerateSystemPromptSection() string {
+	return r.Snapshot().GenerateSystemPromptSection()
 }
 
 // GenerateSkillsInstructions generates instructions for using skills.
 func (r *Registry) GenerateSkillsInstructions() string {
-	return `<skills_instructions>
-When a task matches one of the available skills, follow these steps:
-
-1. **Discovery**: Check <available_skills> to see if any skill matches the current task
-2. **Load Instructions**: Use the view tool or read_file to load the full SKILL.md content from the skill's location
-3. **Follow Instructions**: Execute the task according to the loaded skill instructions
-4. **Reference Files**: If the skill references additional files (scripts/, references/, assets/), load them as needed
+	return r.Snapshot().GenerateSkillsInstructions()
+}
 
-Skills provide specialized workflows and domain knowledge. Always prefer using a relevant skill over improvising when one is available.
-</skills_instructions>
-`
+// RenderPrompt renders the skills section and the usage instructions for a
+// model that can call the given tools. A nil tools uses PromptOptions.Tools.
+func (r *Registry) RenderPrompt(tools []string) Prompt {
+	return r.Snapshot().RenderPrompt(tools)
 }
 
 // Reload refreshes the registry with updated skills from disk.
+// Unlike Initialize, it never starts the watcher.
 func (r *Registry) Reload(ctx context.Context) error {
-	return r.Initialize(ctx)
+	r.reloadMu.Lock()
+	defer r.reloadMu.Unlock()
+	return r.initialize(ctx)
 }
 
 // Count returns the number of registered skills.
 func (r *Registry) Count() int {
-	r.mu.RLock()
-	defer r.mu.RUnlock()
-	return len(r.metadata)
+	return r.Snapshot().Count()
 }
 
 // Names returns all registered skill names.
 func (r *Registry) Names() []string {
-	r.mu.RLock()
-	defer r.mu.RUnlock()
-
-	names := make([]string, len(r.metadata))
-	for i, m := range r.metadata {
-		names[i] = m.Name
-	}
-	return names
+	return r.Snapshot().Names()
 }
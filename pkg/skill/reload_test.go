@@ -0,0 +1,259 @@
+package skill
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestRegistryReloadSkill(t *testing.T) {
+	ctx := context.Background()
+	globalDir := t.TempDir()
+	projectDir := t.TempDir()
+	writeTestSkill(t, filepath.Join(projectDir, "deploy"), "deploy", "Deploy services", "")
+	writeTestSkill(t, filepath.Join(projectDir, "lint"), "lint", "Lint code", "1.0.0")
+	writeTestSkill(t, filepath.Join(globalDir, "review"), "review", "Review pull requests (global)", "")
+	writeTestSkill(t, filepath.Join(projectDir, "review"), "review", "Review pull requests (project)", "")
+
+	registry := NewRegistry(NewLoader(WithGlobalSkillsDir(globalDir), WithProjectSkillsDir(projectDir)))
+	if err := registry.Initialize(ctx); err != nil {
+		t.Fatalf("Initialize() error: %v", err)
+	}
+
+	deploy, err := registry.Get(ctx, "deploy")
+	if err != nil {
+		t.Fatalf("Get(deploy) error: %v", err)
+	}
+
+	var events []Event
+	registry.Subscribe(func(e Event) {
+		events = append(events, e)
+	})
+
+	description := func(name string) string {
+		m, err := registry.Resolve(name)
+		if err != nil {
+			return ""
+		}
+		return m.Description
+	}
+
+	tests := []struct {
+		name   string
+		change func()
+		dir    string
+		event  EventType
+		check  func(t *testing.T)
+	}{
+		{
+			name:   "edit refreshes only the changed skill",
+			change: func() { writeTestSkill(t, filepath.Join(projectDir, "lint"), "lint", "Lint code", "1.1.0") },
+			dir:    filepath.Join(projectDir, "lint"),
+			event:  EventSkillUpdated,
+			check: func(t *testing.T) {
+				if s, _ := registry.Get(ctx, "lint"); s == nil || s.Version != "1.1.0" {
+					t.Errorf("lint not refreshed: %+v", s)
+				}
+				if s, _ := registry.Get(ctx, "deploy"); s != deploy {
+					t.Error("deploy cache entry was dropped")
+				}
+			},
+		},
+		{
+			name:   "new directory adds a skill",
+			change: func() { writeTestSkill(t, filepath.Join(projectDir, "docs"), "docs", "Write docs", "") },
+			dir:    filepath.Join(projectDir, "docs"),
+			event:  EventSkillAdded,
+			check: func(t *testing.T) {
+				if registry.Count() != 4 {
+					t.Errorf("Count() = %d, want 4", registry.Count())
+				}
+			},
+		},
+		{
+			name:   "removed directory drops the skill",
+			change: func() { _ = os.RemoveAll(filepath.Join(projectDir, "deploy")) },
+			dir:    filepath.Join(projectDir, "deploy"),
+			event:  EventSkillRemoved,
+			check: func(t *testing.T) {
+				if _, err := registry.Resolve("deploy"); err == nil {
+					t.Error("deploy still registered")
+				}
+			},
+		},
+		{
+			name:   "removing a project skill reveals the global one",
+			change: func() { _ = os.RemoveAll(filepath.Join(projectDir, "review")) },
+			dir:    filepath.Join(projectDir, "review"),
+			event:  EventSkillUpdated,
+			check: func(t *testing.T) {
+				if got := description("review"); got != "Review pull requests (global)" {
+					t.Errorf("review description = %q", got)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			events = nil
+			tt.change()
+			if err := registry.ReloadSkill(ctx, tt.dir); err != nil {
+				t.Fatalf("ReloadSkill() error: %v", err)
+			}
+			if len(events) != 1 || events[0].Type != tt.event {
+				t.Fatalf("events = %+v, want one %s", events, tt.event)
+			}
+			tt.check(t)
+		})
+	}
+
+	if err := registry.ReloadSkill(ctx, t.TempDir()); err == nil {
+		t.Error("ReloadSkill() outside the skills directories expected error")
+	}
+}
+
+func TestRegistryLastKnownGood(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	skillDir := filepath.Join(dir, "deploy")
+	writeTestSkill(t, skillDir, "deploy", "Deploy services", "1.0.0")
+
+	registry := NewRegistry(NewLoader(WithGlobalSkillsDir(filepath.Join(dir, "missing")), WithProjectSkillsDir(dir)))
+	if err := registry.Initialize(ctx); err != nil {
+		t.Fatalf("Initialize() error: %v", err)
+	}
+	cached, err := registry.Get(ctx, "deploy")
+	if err != nil {
+		t.Fatalf("Get() error: %v", err)
+	}
+
+	var events []EventType
+	registry.Subscribe(func(e Event) {
+		events = append(events, e.Type)
+	})
+
+	skillMD := filepath.Join(skillDir, SkillFileName)
+	broken := "---\nname: deploy\ndescription: [unterminated\n---\n\n# deploy\n"
+
+	tests := []struct {
+		name   string
+		change func()
+		reload func() error
+		events []EventType
+		stale  bool
+		gone   bool
+	}{
+		{
+			name:   "invalid edit keeps the last valid version",
+			change: func() { _ = os.WriteFile(skillMD, []byte(broken), 0644) },
+			reload: func() error { return registry.ReloadSkill(ctx, skillDir) },
+			events: []EventType{EventSkillUpdated, EventSkillInvalid},
+			stale:  true,
+		},
+		{
+			name:   "full reload keeps it stale without repeating the error",
+			change: func() {},
+			reload: func() error { return registry.Reload(ctx) },
+			stale:  true,
+		},
+		{
+			name:   "fix clears the stale flag",
+			change: func() { writeTestSkill(t, skillDir, "deploy", "Deploy services", "1.0.1") },
+			reload: func() error { return registry.ReloadSkill(ctx, skillDir) },
+			events: []EventType{EventSkillUpdated},
+		},
+		{
+			name:   "deleting SKILL.md keeps the skill while the directory exists",
+			change: func() { _ = os.Remove(skillMD) },
+			reload: func() error { return registry.Reload(ctx) },
+			events: []EventType{EventSkillUpdated, EventSkillInvalid},
+			stale:  true,
+		},
+		{
+			name:   "deleting the directory drops the skill",
+			change: func() { _ = os.RemoveAll(skillDir) },
+			reload: func() error { return registry.ReloadSkill(ctx, skillDir) },
+			events: []EventType{EventSkillRemoved},
+			gone:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			events = nil
+			tt.change()
+			if err := tt.reload(); err != nil {
+				t.Fatalf("reload error: %v", err)
+			}
+			if !slices.Equal(events, tt.events) {
+				t.Errorf("events = %v, want %v", events, tt.events)
+			}
+
+			report := registry.LoadReport()
+			m, err := registry.Resolve("deploy")
+			if tt.gone {
+				if err == nil || len(report.Failures) != 0 {
+					t.Errorf("skill still registered: %+v, report %+v", m, report)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Resolve() error: %v", err)
+			}
+			if m.Stale != tt.stale {
+				t.Errorf("Stale = %v, want %v", m.Stale, tt.stale)
+			}
+			if tt.stale {
+				if len(report.Failures) != 1 || report.Failures[0].Name != "deploy" || len(report.Stale) != 1 {
+					t.Errorf("report = %+v, want one failure and one stale skill", report)
+				}
+				if s, err := registry.Get(ctx, "deploy"); err != nil || s != cached {
+					t.Errorf("Get() = %v, %v; want the cached valid version", s, err)
+				}
+				return
+			}
+			if len(report.Failures) != 0 {
+				t.Errorf("report.Failures = %+v, want none", report.Failures)
+			}
+			if cached, err = registry.Get(ctx, "deploy"); err != nil || cached.Version != m.Version {
+				t.Errorf("Get() = %v, %v; want version %s", cached, err, m.Version)
+			}
+		})
+	}
+}
+
+func TestRegistryLastKnownGoodUncached(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	skillDir := filepath.Join(dir, "deploy")
+	writeTestSkill(t, skillDir, "deploy", "Deploy services", "1.0.0")
+
+	registry := NewRegistry(NewLoader(WithGlobalSkillsDir(filepath.Join(dir, "missing")), WithProjectSkillsDir(dir)))
+	if err := registry.Initialize(ctx); err != nil {
+		t.Fatalf("Initialize() error: %v", err)
+	}
+
+	// The skill is never fetched before the invalid edit, so no valid copy
+	// is kept; it stays listed but cannot be loaded
+	broken := "---\nname: deploy\ndescription: [unterminated\n---\n\n# deploy\n\nBroken instructions.\n"
+	if err := os.WriteFile(filepath.Join(skillDir, SkillFileName), []byte(broken), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := registry.Reload(ctx); err != nil {
+		t.Fatalf("Reload() error: %v", err)
+	}
+
+	if report := registry.LoadReport(); len(report.Stale) != 1 || report.Stale[0].Version != "1.0.0" {
+		t.Errorf("LoadReport().Stale = %+v, want deploy 1.0.0", report.Stale)
+	}
+	if _, err := registry.Get(ctx, "deploy"); err == nil || !strings.Contains(err.Error(), "not cached") {
+		t.Errorf("Get() error = %v, want the load failure of an uncached stale skill", err)
+	}
+	if stats := registry.Stats(); stats.Entries != 0 {
+		t.Errorf("Stats().Entries = %d, want no copy of the broken SKILL.md", stats.Entries)
+	}
+}
@@ -0,0 +1,238 @@
+package skill
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"text/template"
+)
+
+// Names of the skill tools prompts refer to; see package tools.
+const (
+	ToolViewSkill          = "view_skill"
+	ToolListSkills         = "list_skills"
+	ToolSearchSkills       = "search_skills"
+	ToolRunTerminalCommand = "run_terminal_command"
+)
+
+// DefaultPromptTools are the tools prompts assume when none are given:
+// the tools created by tools.NewSkillTools.
+var DefaultPromptTools = []string{ToolViewSkill, ToolListSkills, ToolSearchSkills}
+
+// PromptData is the input of a PromptRenderer.
+type PromptData struct {
+	// Skills are the skills to list, in name order
+	Skills []SkillMetadata
+
+	// Omitted is the number of skills left out to fit the token budget
+	Omitted int
+
+	// Tools are the names of the tools the model can call
+	Tools []string
+}
+
+// HasTool reports whether the model can call the named tool.
+func (d PromptData) HasTool(name string) bool {
+	return slices.Contains(d.Tools, name)
+}
+
+// PromptRenderer formats the skills section of the system prompt and the
+// instructions for using skills.
+type PromptRenderer interface {
+	// RenderSkills formats the list of available skills
+	RenderSkills(data PromptData) (string, error)
+
+	// RenderInstructions formats the instructions for using skills
+	RenderInstructions(data PromptData) (string, error)
+}
+
+// XMLRenderer renders skills as <available_skills> XML, the default format.
+type XMLRenderer struct{}
+
+// RenderSkills implements PromptRenderer.
+func (XMLRenderer) RenderSkills(data PromptData) (string, error) {
+	var sb strings.Builder
+	sb.WriteString("<available_skills>\n")
+	for _, m := range data.Skills {
+		sb.WriteString("<skill>\n")
+		sb.WriteString(fmt.Sprintf("<name>\n%s\n</name>\n", m.Name))
+		sb.WriteString(fmt.Sprintf("<description>\n%s\n</description>\n", m.Description))
+		if m.Version != "" {
+			sb.WriteString(fmt.Sprintf("<version>\n%s\n</version>\n", m.Version))
+		}
+		if m.Path != "" {
+			sb.WriteString(fmt.Sprintf("<location>\n%s/SKILL.md\n</location>\n", m.Path))
+		}
+		sb.WriteString("</skill>\n\n")
+	}
+	if data.Omitted > 0 {
+		sb.WriteString(fmt.Sprintf("<more_skills>\n%s\n</more_skills>\n", moreSkillsText(data)))
+	}
+	sb.WriteString("</available_skills>\n")
+	return sb.String(), nil
+}
+
+// RenderInstructions implements PromptRenderer.
+func (XMLRenderer) RenderInstructions(data PromptData) (string, error) {
+	return "<skills_instructions>\n" + instructionsText(data, "<available_skills>") + "</skills_instructions>\n", nil
+}
+
+// MarkdownRenderer renders skills as a Markdown list.
+type MarkdownRenderer struct{}
+
+// RenderSkills implements PromptRenderer.
+func (MarkdownRenderer) RenderSkills(data PromptData) (string, error) {
+	var sb strings.Builder
+	sb.WriteString("## Available Skills\n\n")
+	for _, m := range data.Skills {
+		sb.WriteString(fmt.Sprintf("- **%s**", m.Name))
+		if m.Version != "" {
+			sb.WriteString(fmt.Sprintf(" (v%s)", m.Version))
+		}
+		sb.WriteString(fmt.Sprintf(": %s\n", m.Description))
+		if m.Path != "" {
+			sb.WriteString(fmt.Sprintf("  Location: `%s/SKILL.md`\n", m.Path))
+		}
+	}
+	if data.Omitted > 0 {
+		sb.WriteString(fmt.Sprintf("\n_%s._\n", moreSkillsText(data)))
+	}
+	return sb.String(), nil
+}
+
+// RenderInstructions implements PromptRenderer.
+func (MarkdownRenderer) RenderInstructions(data PromptData) (string, error) {
+	return "## Using Skills\n\n" + instructionsText(data, "Available Skills"), nil
+}
+
+// JSONRenderer renders skills as a compact JSON document.
+type JSONRenderer struct{}
+
+// jsonPromptSkill is a skill in the JSON prompt format.
+type jsonPromptSkill struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Version     string `json:"version,omitempty"`
+	Location    string `json:"location,omitempty"`
+}
+
+// RenderSkills implements PromptRenderer.
+func (JSONRenderer) RenderSkills(data PromptData) (string, error) {
+	doc := struct {
+		Skills []jsonPromptSkill `json:"available_skills"`
+		More   string            `json:"more_skills,omitempty"`
+	}{Skills: make([]jsonPromptSkill, 0, len(data.Skills))}
+
+	for _, m := range data.Skills {
+		s := jsonPromptSkill{Name: m.Name, Description: m.Description, Version: m.Version}
+		if m.Path != "" {
+			s.Location = m.Path + "/SKILL.md"
+		}
+		doc.Skills = append(doc.Skills, s)
+	}
+	if data.Omitted > 0 {
+		doc.More = moreSkillsText(data)
+	}
+
+	out, err := json.Marshal(doc)
+	if err != nil {
+		return "", err
+	}
+	return string(out) + "\n", nil
+}
+
+// RenderInstructions implements PromptRenderer.
+func (JSONRenderer) RenderInstructions(data PromptData) (string, error) {
+	return instructionsText(data, "available_skills"), nil
+}
+
+// TemplateRenderer renders the prompt with text/template templates. The
+// templates receive a PromptData and may call the functions "instructions"
+// (the default instruction text), "more" (the omitted skills line) and
+// "hasTool".
+type TemplateRenderer struct {
+	skills       *template.Template
+	instructions *template.Template
+}
+
+// NewTemplateRenderer parses the templates for the skills section and the
+// instructions. An empty instructions template uses the default text.
+func NewTemplateRenderer(skills, instructions string) (*TemplateRenderer, error) {
+	funcs := template.FuncMap{
+		"instructions": func(d PromptData) string { return instructionsText(d, "the available skills") },
+		"more":         moreSkillsText,
+		"hasTool":      func(d PromptData, name string) bool { return d.HasTool(name) },
+	}
+	if instructions == "" {
+		instructions = "{{instructions .}}"
+	}
+
+	r := &TemplateRenderer{}
+	var err error
+	if r.skills, err = template.New("skills").Funcs(funcs).Parse(skills); err != nil {
+		return nil, fmt.Errorf("invalid skills template: %w", err)
+	}
+	if r.instructions, err = template.New("instructions").Funcs(funcs).Parse(instructions); err != nil {
+		return nil, fmt.Errorf("invalid instructions template: %w", err)
+	}
+	return r, nil
+}
+
+// RenderSkills implements PromptRenderer.
+func (r *TemplateRenderer) RenderSkills(data PromptData) (string, error) {
+	var buf bytes.Buffer
+	err := r.skills.Execute(&buf, data)
+	return buf.String(), err
+}
+
+// RenderInstructions implements PromptRenderer.
+func (r *TemplateRenderer) RenderInstructions(data PromptData) (string, error) {
+	var buf bytes.Buffer
+	err := r.instructions.Execute(&buf, data)
+	return buf.String(), err
+}
+
+// moreSkillsText summarizes the skills left out of the prompt.
+func moreSkillsText(data PromptData) string {
+	if data.HasTool(ToolListSkills) {
+		return fmt.Sprintf("%d more skills, use %s to see them", data.Omitted, ToolListSkills)
+	}
+	return fmt.Sprintf("%d more skills are installed but not listed", data.Omitted)
+}
+
+// instructionsText explains how to use skills with the tools the model can
+// call. list names the skills list as the renderer formats it.
+func instructionsText(data PromptData, list string) string {
+	var sb strings.Builder
+	sb.WriteString("When a task matches one of the available skills, follow these steps:\n\n")
+
+	sb.WriteString(fmt.Sprintf("1. **Discovery**: Check %s to see if any skill matches the current task", list))
+	switch {
+	case data.HasTool(ToolListSkills) && data.HasTool(ToolSearchSkills):
+		sb.WriteString(fmt.Sprintf("; use %s to find skills not listed there and %s to search inside skill instructions", ToolListSkills, ToolSearchSkills))
+	case data.HasTool(ToolListSkills):
+		sb.WriteString(fmt.Sprintf("; use %s to find skills not listed there", ToolListSkills))
+	case data.HasTool(ToolSearchSkills):
+		sb.WriteString(fmt.Sprintf("; use %s to search inside skill instructions", ToolSearchSkills))
+	}
+	sb.WriteString("\n")
+
+	if data.HasTool(ToolViewSkill) {
+		sb.WriteString(fmt.Sprintf("2. **Load Instructions**: Call %s with the skill name to load its full SKILL.md content\n", ToolViewSkill))
+	} else {
+		sb.WriteString("2. **Load Instructions**: Read the SKILL.md file at the skill's location to load its full instructions\n")
+	}
+
+	sb.WriteString("3. **Follow Instructions**: Execute the task according to the loaded skill instructions\n")
+
+	sb.WriteString("4. **Reference Files**: If the skill references additional files (scripts/, references/, assets/), load them as needed")
+	if data.HasTool(ToolRunTerminalCommand) {
+		sb.WriteString(fmt.Sprintf("; run scripts with %s", ToolRunTerminalCommand))
+	}
+	sb.WriteString("\n\n")
+
+	sb.WriteString("Skills provide specialized workflows and domain knowledge. Always prefer using a relevant skill over improvising when one is available.\n")
+	return sb.String()
+}
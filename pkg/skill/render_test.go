@@ -0,0 +1,102 @@
+package skill
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestPromptRenderers(t *testing.T) {
+	skills := []SkillMetadata{
+		{Name: "deploy", Description: "Deploy services", Version: "1.2.0", Path: "/skills/deploy"},
+		{Name: "notes", Description: "Take meeting notes"},
+	}
+	tmpl, err := NewTemplateRenderer("{{range .Skills}}* {{.Name}}: {{.Description}}\n{{end}}{{if .Omitted}}{{more .}}\n{{end}}", "")
+	if err != nil {
+		t.Fatalf("NewTemplateRenderer() error: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		renderer PromptRenderer
+		contains []string
+	}{
+		{name: "xml", renderer: XMLRenderer{}, contains: []string{"<available_skills>", "<name>\ndeploy\n</name>", "<location>\n/skills/deploy/SKILL.md\n</location>", "<more_skills>"}},
+		{name: "markdown", renderer: MarkdownRenderer{}, contains: []string{"## Available Skills", "- **deploy** (v1.2.0): Deploy services", "`/skills/deploy/SKILL.md`"}},
+		{name: "json", renderer: JSONRenderer{}, contains: []string{`"name":"deploy"`, `"location":"/skills/deploy/SKILL.md"`, `"more_skills"`}},
+		{name: "template", renderer: tmpl, contains: []string{"* deploy: Deploy services", "* notes: Take meeting notes"}},
+	}
+
+	data := PromptData{Skills: skills, Omitted: 3, Tools: DefaultPromptTools}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			section, err := tt.renderer.RenderSkills(data)
+			if err != nil {
+				t.Fatalf("RenderSkills() error: %v", err)
+			}
+			for _, want := range append(tt.contains, "3 more skills, use list_skills") {
+				if !strings.Contains(section, want) {
+					t.Errorf("section missing %q:\n%s", want, section)
+				}
+			}
+			if tt.name == "json" && !json.Valid([]byte(section)) {
+				t.Errorf("invalid JSON: %s", section)
+			}
+
+			instructions, err := tt.renderer.RenderInstructions(data)
+			if err != nil {
+				t.Fatalf("RenderInstructions() error: %v", err)
+			}
+			if !strings.Contains(instructions, "Call view_skill") || strings.Contains(instructions, "read_file") {
+				t.Errorf("instructions do not refer to view_skill:\n%s", instructions)
+			}
+		})
+	}
+}
+
+func TestInstructionsFollowTools(t *testing.T) {
+	tests := []struct {
+		name    string
+		tools   []string
+		want    []string
+		notWant []string
+	}{
+		{name: "default tools", tools: DefaultPromptTools, want: []string{"Call view_skill", "use list_skills", "search_skills"}, notWant: []string{"run_terminal_command"}},
+		{name: "view only", tools: []string{ToolViewSkill}, want: []string{"Call view_skill"}, notWant: []string{"list_skills", "search_skills"}},
+		{name: "no tools", tools: []string{}, want: []string{"Read the SKILL.md file"}, notWant: []string{"view_skill"}},
+		{name: "terminal", tools: []string{ToolViewSkill, ToolRunTerminalCommand}, want: []string{"run scripts with run_terminal_command"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			instructions, _ := XMLRenderer{}.RenderInstructions(PromptData{Tools: tt.tools})
+			for _, want := range tt.want {
+				if !strings.Contains(instructions, want) {
+					t.Errorf("instructions missing %q:\n%s", want, instructions)
+				}
+			}
+			for _, notWant := range tt.notWant {
+				if strings.Contains(instructions, notWant) {
+					t.Errorf("instructions mention %q:\n%s", notWant, instructions)
+				}
+			}
+		})
+	}
+}
+
+func TestTemplateRendererErrors(t *testing.T) {
+	if _, err := NewTemplateRenderer("{{.Skills", ""); err == nil {
+		t.Error("NewTemplateRenderer() accepted an invalid template")
+	}
+
+	// Execution errors fall back to the XML format
+	broken, err := NewTemplateRenderer("{{.Missing}}", "")
+	if err != nil {
+		t.Fatalf("NewTemplateRenderer() error: %v", err)
+	}
+	registry := NewRegistry(NewLoader(), WithPromptOptions(PromptOptions{Renderer: broken}))
+	registry.snapshot.Store(&Snapshot{registry: registry, metadata: []SkillMetadata{{Name: "deploy", Description: "Deploy services"}}})
+	if section := registry.GenerateSystemPromptSection(); !strings.Contains(section, "<available_skills>") {
+		t.Errorf("GenerateSystemPromptSection() = %q, want XML fallback", section)
+	}
+}
@@ -0,0 +1,128 @@
+package skill
+
+import (
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// LoadFailure is a skill directory whose SKILL.md could not be loaded.
+type LoadFailure struct {
+	// Path is the skill directory
+	Path string `json:"path"`
+
+	// Source is the skills directory the skill belongs to
+	Source SkillSource `json:"source"`
+
+	// Name is the last valid skill name, "" if the skill never loaded.
+	// A named failure means the registry serves that version as stale.
+	Name string `json:"name,omitempty"`
+
+	// Err is the parse or validation error
+	Err error `json:"-"`
+}
+
+// LoadReport summarizes the outcome of the most recent (re)load.
+type LoadReport struct {
+	// Time is when the registry last changed
+	Time time.Time `json:"time"`
+
+	// Loaded is the number of registered skills, including stale ones
+	Loaded int `json:"loaded"`
+
+	// Stale lists skills served from their last valid version
+	Stale []SkillMetadata `json:"stale,omitempty"`
+
+	// Failures lists skill directories that currently fail to load
+	Failures []LoadFailure `json:"failures,omitempty"`
+}
+
+// LoadReport returns the outcome of the most recent Initialize, Reload or
+// ReloadSkill, including skills served stale after an invalid edit.
+func (r *Registry) LoadReport() LoadReport {
+	return r.Snapshot().LoadReport()
+}
+
+// keepLastKnownGood re-registers previously valid skills whose directory
+// still exists but no longer loads, marking them stale. Stale skills are
+// served from their pinned cache entry; one that was not cached before the
+// edit keeps its metadata but cannot be loaded. Directories that lost their
+// SKILL.md are added to failures. It returns the updated failures; names of
+// re-registered skills are added to changed.
+func keepLastKnownGood(previous []SkillMetadata, versions map[string][]SkillMetadata, failures []LoadFailure, changed map[string]bool) []LoadFailure {
+	loaded := make(map[string]bool)
+	for _, vs := range versions {
+		for _, v := range vs {
+			loaded[filepath.Clean(v.Path)] = true
+		}
+	}
+	failed := make(map[string]int, len(failures))
+	for i, f := range failures {
+		failed[filepath.Clean(f.Path)] = i
+	}
+
+	for _, old := range previous {
+		path := filepath.Clean(old.Path)
+		if loaded[path] {
+			continue
+		}
+
+		i, ok := failed[path]
+		if !ok {
+			// Only a deleted directory drops the skill, or a SKILL.md
+			// that loaded but was left out by the config
+			if _, err := os.Stat(path); err != nil {
+				continue
+			}
+			if _, err := os.Stat(filepath.Join(path, SkillFileName)); err == nil {
+				continue
+			}
+			failures = append(failures, LoadFailure{
+				Path:   old.Path,
+				Source: old.Source,
+				Err:    &SkillError{SkillPath: old.Path, Message: ErrMissingSkillMD.Message},
+			})
+			i = len(failures) - 1
+			failed[path] = i
+		}
+
+		failures[i].Name = old.Name
+		old.Stale = true
+		addVersion(versions, old)
+		changed[old.Name] = true
+	}
+
+	for name := range changed {
+		if vs := versions[name]; len(vs) > 0 {
+			sortByVersionDesc(vs)
+		}
+	}
+	return failures
+}
+
+// failureEvents returns EventSkillInvalid events for failures that are new
+// or whose error changed since the previous report.
+func failureEvents(previous, current []LoadFailure, versions map[string][]SkillMetadata) []Event {
+	before := make(map[string]string, len(previous))
+	for _, f := range previous {
+		before[filepath.Clean(f.Path)] = f.Err.Error()
+	}
+
+	now := time.Now()
+	var events []Event
+	for _, f := range current {
+		if msg, ok := before[filepath.Clean(f.Path)]; ok && msg == f.Err.Error() {
+			continue
+		}
+
+		m := SkillMetadata{Path: f.Path, Source: f.Source}
+		for _, v := range versions[f.Name] {
+			if samePath(v.Path, f.Path) {
+				m = v
+				break
+			}
+		}
+		events = append(events, Event{Type: EventSkillInvalid, Name: f.Name, Metadata: m, Err: f.Err, Time: now})
+	}
+	return events
+}
@@ -0,0 +1,256 @@
+package skill
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/cloudwego/eino/components/embedding"
+)
+
+// SearchMode selects how Registry.Search ranks skills.
+type SearchMode string
+
+const (
+	// SearchModeLexical ranks with the BM25 keyword index only
+	SearchModeLexical SearchMode = "lexical"
+
+	// SearchModeSemantic ranks by embedding cosine similarity only
+	SearchModeSemantic SearchMode = "semantic"
+
+	// SearchModeHybrid blends normalized BM25 scores with cosine similarity
+	SearchModeHybrid SearchMode = "hybrid"
+)
+
+// searchConfig holds the semantic search settings of a Registry.
+type searchConfig struct {
+	embedder       embedding.Embedder
+	mode           SearchMode
+	semanticWeight float64
+	minSimilarity  float64
+	cache          *vectorCache
+}
+
+// defaultSearchConfig returns the settings used when no search options are given.
+func defaultSearchConfig() searchConfig {
+	return searchConfig{
+		semanticWeight: 0.6,
+		minSimilarity:  0.5,
+		cache:          &vectorCache{vectors: make(map[string][]float64)},
+	}
+}
+
+// vectorCache keeps embeddings by input text so reloads only embed
+// skills whose name, description, tags or triggers changed.
+type vectorCache struct {
+	mu      sync.Mutex
+	vectors map[string][]float64
+}
+
+// WithEmbedder enables semantic skill retrieval with an eino embedder.
+// Skill names, descriptions, tags and triggers are embedded at Initialize;
+// vectors are cached across reloads. Search defaults to hybrid mode.
+func WithEmbedder(embedder embedding.Embedder) RegistryOption {
+	return func(r *Registry) {
+		r.search.embedder = embedder
+	}
+}
+
+// WithSearchMode selects lexical, semantic or hybrid ranking for Search.
+// Semantic and hybrid modes fall back to lexical without an embedder.
+func WithSearchMode(mode SearchMode) RegistryOption {
+	return func(r *Registry) {
+		r.search.mode = mode
+	}
+}
+
+// WithSemanticWeight sets the share of cosine similarity in hybrid scores
+// (0..1, default 0.6); the rest comes from the normalized BM25 score.
+func WithSemanticWeight(weight float64) RegistryOption {
+	return func(r *Registry) {
+		r.search.semanticWeight = math.Max(0, math.Min(1, weight))
+	}
+}
+
+// WithMinSimilarity sets the cosine similarity a skill needs to be returned
+// by semantic search without a lexical match. Default: 0.5
+func WithMinSimilarity(threshold float64) RegistryOption {
+	return func(r *Registry) {
+		r.search.minSimilarity = threshold
+	}
+}
+
+// embeddingText returns the text embedded for a skill.
+func embeddingText(m SkillMetadata) string {
+	var sb strings.Builder
+	sb.WriteString(m.Name)
+	sb.WriteString(": ")
+	sb.WriteString(m.Description)
+	if len(m.Triggers) > 0 {
+		sb.WriteString("\nTriggers: ")
+		sb.WriteString(strings.Join(m.Triggers, "; "))
+	}
+	if len(m.Tags) > 0 {
+		sb.WriteString("\nTags: ")
+		sb.WriteString(strings.Join(m.Tags, ", "))
+	}
+	return sb.String()
+}
+
+// embedSkills returns embedding vectors keyed by skill reference. Only texts
+// missing from the cache are sent to the embedder. It returns nil when no
+// embedder is configured.
+func (r *Registry) embedSkills(ctx context.Context, metadata []SkillMetadata) (map[string][]float64, error) {
+	if r.search.embedder == nil {
+		return nil, nil
+	}
+
+	cache := r.search.cache
+	texts := make(map[string]string, len(metadata))
+	var missing []string
+	cache.mu.Lock()
+	for _, m := range metadata {
+		text := embeddingText(m)
+		texts[m.Ref()] = text
+		if _, ok := cache.vectors[text]; !ok {
+			missing = append(missing, text)
+		}
+	}
+	cache.mu.Unlock()
+
+	// The embedder may be remote; do not hold the cache lock while it runs
+	var embedded [][]float64
+	if len(missing) > 0 {
+		var err error
+		embedded, err = r.search.embedder.EmbedStrings(ctx, missing)
+		if err != nil {
+			return nil, err
+		}
+		if len(embedded) != len(missing) {
+			return nil, fmt.Errorf("embedder returned %d vectors for %d texts", len(embedded), len(missing))
+		}
+	}
+
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+	for i, text := range missing {
+		cache.vectors[text] = embedded[i]
+	}
+
+	// Keep only vectors of current skills in the cache
+	current := make(map[string][]float64, len(texts))
+	result := make(map[string][]float64, len(texts))
+	for ref, text := range texts {
+		current[text] = cache.vectors[text]
+		result[ref] = cache.vectors[text]
+	}
+	cache.vectors = current
+
+	return result, nil
+}
+
+// searchMode returns the effective search mode.
+func (r *Registry) searchMode() SearchMode {
+	if r.search.embedder == nil {
+		return SearchModeLexical
+	}
+	if r.search.mode == "" {
+		return SearchModeHybrid
+	}
+	return r.search.mode
+}
+
+// Search ranks skills against a natural-language query and returns the top
+// k matches (all matches for k <= 0). Without an embedder it is equivalent
+// to FindMatchingSkills; with one it uses semantic or hybrid ranking as
+// configured by WithSearchMode.
+func (r *Registry) Search(ctx context.Context, query string, k int) ([]SkillMatch, error) {
+	return r.Snapshot().Search(ctx, query, k)
+}
+
+// Search ranks the snapshot's skills against a query; see Registry.Search.
+func (s *Snapshot) Search(ctx context.Context, query string, k int) ([]SkillMatch, error) {
+	r := s.registry
+	mode := r.searchMode()
+	if mode == SearchModeLexical || s.vectors == nil {
+		return s.FindMatchingSkills(query, k), nil
+	}
+
+	queryVectors, err := r.search.embedder.EmbedStrings(ctx, []string{query})
+	if err != nil {
+		return nil, fmt.Errorf("failed to embed query: %w", err)
+	}
+	if len(queryVectors) != 1 {
+		return nil, fmt.Errorf("embedder returned %d vectors for 1 query", len(queryVectors))
+	}
+	queryVector := queryVectors[0]
+
+	lexical := s.index.search(query, 0, minMatchEvidence)
+
+	// Normalize BM25 scores to 0..1 by the best lexical score
+	lexicalScores := make(map[string]float64, len(lexical))
+	maxLexical := 0.0
+	for _, m := range lexical {
+		maxLexical = math.Max(maxLexical, m.Score)
+	}
+	for _, m := range lexical {
+		lexicalScores[m.Metadata.Ref()] = m.Score / maxLexical
+	}
+
+	weight := r.search.semanticWeight
+	var matches []SkillMatch
+	for _, m := range s.metadata {
+		similarity := cosineSimilarity(queryVector, s.vectors[m.Ref()])
+		lexicalScore, lexicalHit := lexicalScores[m.Ref()]
+
+		var score float64
+		switch mode {
+		case SearchModeSemantic:
+			if similarity < r.search.minSimilarity {
+				continue
+			}
+			score = similarity
+		default:
+			if similarity < r.search.minSimilarity && !lexicalHit {
+				continue
+			}
+			score = weight*math.Max(similarity, 0) + (1-weight)*lexicalScore
+		}
+
+		matches = append(matches, SkillMatch{Metadata: cloneMetadata(m), Score: score})
+	}
+
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].Score != matches[j].Score {
+			return matches[i].Score > matches[j].Score
+		}
+		return matches[i].Metadata.Name < matches[j].Metadata.Name
+	})
+
+	if k > 0 && len(matches) > k {
+		matches = matches[:k]
+	}
+	return matches, nil
+}
+
+// cosineSimilarity returns the cosine of the angle between two vectors,
+// or 0 if they differ in length or either is zero.
+func cosineSimilarity(a, b []float64) float64 {
+	if len(a) == 0 || len(a) != len(b) {
+		return 0
+	}
+
+	var dot, normA, normB float64
+	for i := range a {
+		dot += a[i] * b[i]
+		normA += a[i] * a[i]
+		normB += b[i] * b[i]
+	}
+	if normA == 0 || normB == 0 {
+		return 0
+	}
+	return dot / (math.Sqrt(normA) * math.Sqrt(normB))
+}
@@ -0,0 +1,114 @@
+package skill
+
+import (
+	"context"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/cloudwego/eino/components/embedding"
+)
+
+// fakeEmbedder maps words to fixed concept dimensions so that synonyms
+// embed close together, deterministically.
+type fakeEmbedder struct {
+	calls int
+	texts int
+}
+
+var fakeConcepts = map[string]int{
+	"deploy": 0, "ship": 0, "release": 0, "production": 0, "rollout": 0,
+	"commit": 1, "git": 1, "message": 1,
+	"database": 2, "sql": 2, "query": 2, "migration": 2,
+}
+
+func (e *fakeEmbedder) EmbedStrings(ctx context.Context, texts []string, opts ...embedding.Option) ([][]float64, error) {
+	e.calls++
+	e.texts += len(texts)
+	vectors := make([][]float64, len(texts))
+	for i, text := range texts {
+		v := make([]float64, 4)
+		v[3] = 0.1
+		for _, word := range strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+			return r < 'a' || r > 'z'
+		}) {
+			if dim, ok := fakeConcepts[word]; ok {
+				v[dim]++
+			}
+		}
+		vectors[i] = v
+	}
+	return vectors, nil
+}
+
+func TestRegistrySearch(t *testing.T) {
+	dir := t.TempDir()
+	writeTestSkill(t, filepath.Join(dir, "deployer"), "deployer", "Deploy services to production", "")
+	writeTestSkill(t, filepath.Join(dir, "git-commit"), "git-commit", "Write a git commit message", "")
+	writeTestSkill(t, filepath.Join(dir, "db-migrate"), "db-migrate", "Run database migration scripts", "")
+
+	tests := []struct {
+		name     string
+		mode     SearchMode
+		query    string
+		expected []string
+	}{
+		{name: "semantic synonym", mode: SearchModeSemantic, query: "ship the new rollout", expected: []string{"deployer"}},
+		{name: "semantic sql", mode: SearchModeSemantic, query: "fix my sql query", expected: []string{"db-migrate"}},
+		{name: "hybrid keeps lexical hits", mode: SearchModeHybrid, query: "migrate", expected: []string{"db-migrate"}},
+		{name: "hybrid semantic", mode: SearchModeHybrid, query: "release please", expected: []string{"deployer"}},
+		{name: "lexical ignores synonyms", mode: SearchModeLexical, query: "ship the new rollout", expected: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			loader := NewLoader(WithGlobalSkillsDir(filepath.Join(dir, "missing")), WithProjectSkillsDir(dir))
+			registry := NewRegistry(loader, WithEmbedder(&fakeEmbedder{}), WithSearchMode(tt.mode))
+			if err := registry.Initialize(context.Background()); err != nil {
+				t.Fatalf("Initialize() error: %v", err)
+			}
+
+			matches, err := registry.Search(context.Background(), tt.query, 0)
+			if err != nil {
+				t.Fatalf("Search() error: %v", err)
+			}
+			var names []string
+			for _, m := range matches {
+				names = append(names, m.Metadata.Name)
+			}
+			if !reflect.DeepEqual(names, tt.expected) {
+				t.Errorf("Search(%q) = %v, want %v", tt.query, names, tt.expected)
+			}
+		})
+	}
+}
+
+func TestRegistryEmbeddingCache(t *testing.T) {
+	dir := t.TempDir()
+	writeTestSkill(t, filepath.Join(dir, "deployer"), "deployer", "Deploy services to production", "")
+	writeTestSkill(t, filepath.Join(dir, "git-commit"), "git-commit", "Write a git commit message", "")
+
+	embedder := &fakeEmbedder{}
+	loader := NewLoader(WithGlobalSkillsDir(filepath.Join(dir, "missing")), WithProjectSkillsDir(dir))
+	registry := NewRegistry(loader, WithEmbedder(embedder))
+	ctx := context.Background()
+
+	if err := registry.Initialize(ctx); err != nil {
+		t.Fatalf("Initialize() error: %v", err)
+	}
+	if err := registry.Reload(ctx); err != nil {
+		t.Fatalf("Reload() error: %v", err)
+	}
+	if embedder.texts != 2 {
+		t.Errorf("embedded %d texts after unchanged reload, want 2", embedder.texts)
+	}
+
+	writeTestSkill(t, filepath.Join(dir, "git-commit"), "git-commit", "Write conventional git commit messages", "")
+	if err := registry.Reload(ctx); err != nil {
+		t.Fatalf("Reload() error: %v", err)
+	}
+	if embedder.texts != 3 {
+		t.Errorf("embedded %d texts after one skill changed, want 3", embedder.texts)
+	}
+}
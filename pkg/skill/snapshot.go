@@ -0,0 +1,268 @@
+package skill
+
+import (
+	"context"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Snapshot is an immutable view of the registry's skill set at one
+// generation. Reloads build a new snapshot and swap it in atomically, so a
+// snapshot taken at the start of an agent turn gives consistent answers
+// for listing, prompt generation, matching and viewing even if the skills
+// change on disk meanwhile. Skill content is still loaded on demand and
+// shared with the registry cache.
+type Snapshot struct {
+	registry   *Registry
+	generation uint64
+	loadedAt   time.Time
+	versions   map[string][]SkillMetadata
+	metadata   []SkillMetadata
+	failures   []LoadFailure
+	index      *searchIndex
+	vectors    map[string][]float64
+
+	// filtered snapshots never load skills outside their versions
+	filtered bool
+
+	// unfiltered is the snapshot a filtered snapshot was built from; base
+	// skills are resolved there, so a view may omit the base of a skill
+	unfiltered *Snapshot
+
+	// local holds in-memory skills by reference, e.g. from a session overlay
+	local map[string]*Skill
+
+	// usage records retrievals for prompt priorities; it belongs to the
+	// registry, view or overlay that built the snapshot
+	usage *usageTracker
+
+	// deps is the dependency graph, built on first use
+	depsOnce sync.Once
+	deps     *DependencyGraph
+
+	// content is the full-text index, built on first use
+	contentMu sync.Mutex
+	content   *contentIndex
+}
+
+// Snapshot returns the current registry snapshot.
+func (r *Registry) Snapshot() *Snapshot {
+	return r.snapshot.Load()
+}
+
+// Generation returns the snapshot's version number. It starts at 0 for an
+// uninitialized registry and increases with every change.
+func (s *Snapshot) Generation() uint64 {
+	return s.generation
+}
+
+// LoadedAt returns when the snapshot was built.
+func (s *Snapshot) LoadedAt() time.Time {
+	return s.loadedAt
+}
+
+// Resolve returns the metadata of the installed version a reference points to.
+// The reference may be a bare name, "name@version" or "name@<semver range>".
+func (s *Snapshot) Resolve(ref string) (SkillMetadata, error) {
+	name, constraint := SplitSkillRef(ref)
+	versions, ok := s.versions[name]
+	if !ok {
+		return SkillMetadata{}, &SkillError{SkillPath: ref, Message: ErrSkillNotFound.Message}
+	}
+
+	if constraint == "" {
+		return cloneMetadata(s.registry.defaultVersion(name, versions)), nil
+	}
+
+	m, ok := selectVersion(versions, constraint)
+	if !ok {
+		return SkillMetadata{}, &SkillError{SkillPath: ref, Message: ErrVersionNotFound.Message}
+	}
+	return cloneMetadata(m), nil
+}
+
+// Versions returns all installed versions of a skill, newest first.
+func (s *Snapshot) Versions(name string) []SkillMetadata {
+	versions := s.versions[name]
+	result := make([]SkillMetadata, len(versions))
+	for i, v := range versions {
+		result[i] = cloneMetadata(v)
+	}
+	return result
+}
+
+// Get retrieves a skill by name, loading it on demand if needed.
+// The name may select a version with "name@version" or "name@<semver range>";
+// a bare name resolves to the pinned or newest version.
+func (s *Snapshot) Get(ctx context.Context, name string) (*Skill, error) {
+	skill, err := s.get(ctx, name)
+	if err == nil {
+		skill, err = s.inherit(ctx, skill, nil)
+	}
+	if err == nil {
+		s.usage.touch(skill.Name)
+	}
+	return skill, err
+}
+
+// get implements Get without recording the use.
+func (s *Snapshot) get(ctx context.Context, name string) (*Skill, error) {
+	r := s.registry
+	m, resolveErr := s.Resolve(name)
+	baseName, _ := SplitSkillRef(name)
+	_, known := s.versions[baseName]
+
+	// The skill is installed but no version satisfies the request
+	if resolveErr != nil && (known || s.filtered) {
+		return nil, resolveErr
+	}
+
+	key := name
+	if resolveErr == nil {
+		key = m.Ref()
+	}
+	if skill, ok := s.local[key]; ok {
+		return skill, nil
+	}
+	if skill, ok := r.cache.get(key); ok {
+		return skill, nil
+	}
+	if resolveErr == nil && m.Stale {
+		// Only a cached copy of the last valid version survives the edit
+		return nil, s.staleError(m)
+	}
+
+	// Load on demand; concurrent requests for the same skill in the same
+	// generation share one load
+	loaded := false
+	v, err, _ := r.loads.Do(strconv.FormatUint(s.generation, 10)+"/"+key, func() (any, error) {
+		loaded = true
+
+		var skill *Skill
+		var err error
+		if resolveErr == nil {
+			skill, err = r.loader.loadSingleSkill(ctx, m.Path, m.Source)
+		} else {
+			skill, err = r.loader.LoadSkill(ctx, name)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		// Skills loaded for an outdated snapshot are not cached
+		r.cache.add(key, skill, s.generation)
+		return skill, nil
+	})
+	if !loaded {
+		r.cache.recordShared()
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return v.(*Skill), nil
+}
+
+// staleError returns the load failure of a stale skill that was not cached
+// before its SKILL.md stopped loading.
+func (s *Snapshot) staleError(m SkillMetadata) error {
+	for _, f := range s.failures {
+		if samePath(f.Path, m.Path) {
+			return &SkillError{SkillPath: m.Path, Message: "stale skill was not cached", Err: f.Err}
+		}
+	}
+	return &SkillError{SkillPath: m.Path, Message: "stale skill was not cached"}
+}
+
+// GetContent retrieves the full content of a skill.
+func (s *Snapshot) GetContent(ctx context.Context, name string) (string, error) {
+	skill, err := s.Get(ctx, name)
+	if err != nil {
+		return "", err
+	}
+	return s.skillContent(ctx, skill)
+}
+
+// skillContent returns the full content of a loaded skill.
+func (s *Snapshot) skillContent(ctx context.Context, skill *Skill) (string, error) {
+	if skill.Source == SourceSession || skill.Extends != "" {
+		// Session skills may have no SKILL.md on disk, and the content of
+		// derived skills is merged with their base
+		return skill.Content, nil
+	}
+	return s.registry.loader.LoadSkillContent(ctx, skill)
+}
+
+// GetMetadata returns the metadata of the default version of every skill.
+// The result is a copy and may be modified by the caller.
+func (s *Snapshot) GetMetadata() []SkillMetadata {
+	result := make([]SkillMetadata, len(s.metadata))
+	for i, m := range s.metadata {
+		result[i] = cloneMetadata(m)
+	}
+	return result
+}
+
+// FindMatchingSkill finds a skill that matches the given query.
+// It returns the best BM25 match, or nil if no skill matches strongly enough.
+func (s *Snapshot) FindMatchingSkill(query string) *SkillMetadata {
+	matches := s.FindMatchingSkills(query, 1)
+	if len(matches) == 0 {
+		return nil
+	}
+	return &matches[0].Metadata
+}
+
+// FindMatchingSkills ranks skills against a query using BM25 over names,
+// descriptions, tags and triggers, and returns the top k matches with
+// their scores. Queries may mix English and CJK text. A k <= 0 returns all
+// matches. Weak matches (a single description word) are not returned.
+func (s *Snapshot) FindMatchingSkills(query string, k int) []SkillMatch {
+	matches := s.index.search(query, k, minMatchEvidence)
+	for i := range matches {
+		matches[i].Metadata = cloneMetadata(matches[i].Metadata)
+	}
+	return matches
+}
+
+// Count returns the number of registered skills.
+func (s *Snapshot) Count() int {
+	return len(s.metadata)
+}
+
+// Names returns all registered skill names.
+func (s *Snapshot) Names() []string {
+	names := make([]string, len(s.metadata))
+	for i, m := range s.metadata {
+		names[i] = m.Name
+	}
+	return names
+}
+
+// LoadReport returns the outcome of the load that produced this snapshot,
+// including skills served stale after an invalid edit.
+func (s *Snapshot) LoadReport() LoadReport {
+	report := LoadReport{
+		Time:     s.loadedAt,
+		Loaded:   len(s.metadata),
+		Failures: slices.Clone(s.failures),
+	}
+	for _, vs := range s.versions {
+		for _, v := range vs {
+			if v.Stale {
+				report.Stale = append(report.Stale, cloneMetadata(v))
+			}
+		}
+	}
+	return report
+}
+
+// cloneMetadata returns a copy of m that shares no slices with it.
+func cloneMetadata(m SkillMetadata) SkillMetadata {
+	m.Tags = slices.Clone(m.Tags)
+	m.Triggers = slices.Clone(m.Triggers)
+	m.DependsOn = slices.Clone(m.DependsOn)
+	return m
+}
@@ -0,0 +1,148 @@
+package skill
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"testing"
+)
+
+func TestSnapshotIsolation(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	writeTestSkill(t, filepath.Join(dir, "deploy"), "deploy", "Deploy services", "1.0.0")
+
+	registry := NewRegistry(NewLoader(WithGlobalSkillsDir(filepath.Join(dir, "missing")), WithProjectSkillsDir(dir)))
+	if got := registry.Snapshot().Generation(); got != 0 {
+		t.Fatalf("Generation() before Initialize = %d, want 0", got)
+	}
+	if err := registry.Initialize(ctx); err != nil {
+		t.Fatalf("Initialize() error: %v", err)
+	}
+
+	before := registry.Snapshot()
+	writeTestSkill(t, filepath.Join(dir, "deploy"), "deploy", "Deploy services", "2.0.0")
+	writeTestSkill(t, filepath.Join(dir, "lint"), "lint", "Lint code", "")
+	if err := registry.Reload(ctx); err != nil {
+		t.Fatalf("Reload() error: %v", err)
+	}
+	after := registry.Snapshot()
+
+	tests := []struct {
+		name     string
+		snapshot *Snapshot
+		gen      uint64
+		names    []string
+		version  string
+	}{
+		{name: "old snapshot", snapshot: before, gen: 1, names: []string{"deploy"}, version: "1.0.0"},
+		{name: "new snapshot", snapshot: after, gen: 2, names: []string{"deploy", "lint"}, version: "2.0.0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.snapshot.Generation(); got != tt.gen {
+				t.Errorf("Generation() = %d, want %d", got, tt.gen)
+			}
+			got := tt.snapshot.Names()
+			slices.Sort(got)
+			if !slices.Equal(got, tt.names) {
+				t.Errorf("Names() = %v, want %v", got, tt.names)
+			}
+			m, err := tt.snapshot.Resolve("deploy")
+			if err != nil || m.Version != tt.version {
+				t.Errorf("Resolve(deploy) = %q, %v, want %q", m.Version, err, tt.version)
+			}
+		})
+	}
+
+	metadata := registry.GetMetadata()
+	metadata[0].Name = "changed"
+	if registry.GetMetadata()[0].Name == "changed" {
+		t.Error("GetMetadata() returned the registry's own slice")
+	}
+}
+
+func TestSnapshotConcurrentReload(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	for i := range 5 {
+		name := fmt.Sprintf("skill-%d", i)
+		writeTestSkill(t, filepath.Join(dir, name), name, "Concurrent skill", "1.0.0")
+	}
+
+	registry := NewRegistry(NewLoader(WithGlobalSkillsDir(filepath.Join(dir, "missing")), WithProjectSkillsDir(dir)), WithCacheSize(2))
+	if err := registry.Initialize(ctx); err != nil {
+		t.Fatalf("Initialize() error: %v", err)
+	}
+
+	var wg sync.WaitGroup
+	done := make(chan struct{})
+	errs := make(chan error, 16)
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		defer close(done)
+		for i := range 50 {
+			name := fmt.Sprintf("skill-%d", i%5)
+			content := fmt.Sprintf("---\nname: %s\ndescription: Concurrent skill\nversion: 1.0.%d\n---\n\n# %s\n", name, i, name)
+			_ = os.WriteFile(filepath.Join(dir, name, SkillFileName), []byte(content), 0644)
+			if i%2 == 0 {
+				_ = registry.Reload(ctx)
+			} else {
+				_ = registry.ReloadSkill(ctx, filepath.Join(dir, name))
+			}
+		}
+	}()
+
+	for range 4 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			var last uint64
+			for {
+				select {
+				case <-done:
+					return
+				default:
+				}
+
+				s := registry.Snapshot()
+				if s.Generation() < last {
+					errs <- fmt.Errorf("generation went back from %d to %d", last, s.Generation())
+					return
+				}
+				last = s.Generation()
+
+				metadata := s.GetMetadata()
+				if len(metadata) != s.Count() {
+					errs <- fmt.Errorf("generation %d: %d metadata entries, Count() = %d", last, len(metadata), s.Count())
+					return
+				}
+				for _, m := range metadata {
+					if _, err := s.Resolve(m.Name); err != nil {
+						errs <- fmt.Errorf("generation %d: Resolve(%s): %v", last, m.Name, err)
+						return
+					}
+					_, _ = s.Get(ctx, m.Name)
+				}
+				_ = registry.GenerateSystemPromptSection()
+				_ = registry.FindMatchingSkills("concurrent", 3)
+			}
+		}()
+	}
+
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Error(err)
+	}
+
+	if stats := registry.Stats(); stats.Entries > 2 {
+		t.Errorf("cache holds %d entries, limit is 2", stats.Entries)
+	}
+}
@@ -0,0 +1,235 @@
+package skill
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Step is one entry of a skill workflow extracted from its instructions.
+type Step struct {
+	// Number is the 1-based position of the step in the workflow
+	Number int `json:"number"`
+
+	// Title is the step heading or the first line of the list item
+	Title string `json:"title"`
+
+	// Prose is the step text without its code blocks
+	Prose string `json:"prose,omitempty"`
+
+	// Code holds the fenced code blocks of the step in document order
+	Code []CodeBlock `json:"code,omitempty"`
+}
+
+// CodeBlock is a fenced code block tagged with its info-string language.
+type CodeBlock struct {
+	Language string `json:"language,omitempty"`
+	Code     string `json:"code"`
+}
+
+var (
+	// stepHeadingPattern matches headings such as "Step 1: Analyze" or "Step 2 - Commit".
+	stepHeadingPattern = regexp.MustCompile(`(?i)^step\s+(\d+)\s*[:.)\-–—]?\s*(.*)$`)
+
+	// numberedItemPattern matches top-level ordered list items such as "1. Stage changes".
+	numberedItemPattern = regexp.MustCompile(`^(\d+)[.)]\s+(.*)$`)
+
+	// workflowSections are headings searched first for a numbered list.
+	workflowSections = []string{"Instructions", "Steps", "Workflow", "Procedure", "How to Use", "Usage"}
+)
+
+// ExtractSteps returns the ordered workflow steps of a skill body.
+//
+// Steps come from "Step N" headings when present; otherwise the first
+// numbered list is used, preferring one under a workflow heading such as
+// "Instructions". Fenced code blocks inside a step are returned separately,
+// tagged with their language. It returns nil if no steps are found.
+func (p *Parser) ExtractSteps(body string) []Step {
+	lines := strings.Split(body, "\n")
+
+	if steps := stepsFromHeadings(lines); len(steps) > 0 {
+		return steps
+	}
+
+	for _, heading := range workflowSections {
+		if section := p.ExtractSection(body, heading); section != "" {
+			if steps := stepsFromList(strings.Split(section, "\n")); len(steps) > 0 {
+				return steps
+			}
+		}
+	}
+
+	return stepsFromList(lines)
+}
+
+// stepsFromHeadings builds steps from "Step N" headings.
+func stepsFromHeadings(lines []string) []Step {
+	headings := scanHeadings(lines)
+
+	var steps []Step
+	for i, h := range headings {
+		m := stepHeadingPattern.FindStringSubmatch(h.text)
+		if m == nil {
+			continue
+		}
+
+		title := strings.TrimSpace(m[2])
+		if title == "" {
+			title = h.text
+		}
+
+		end := sectionEnd(headings, i, len(lines))
+		steps = append(steps, buildStep(len(steps)+1, title, lines[h.line+1:end]))
+	}
+
+	return steps
+}
+
+// stepsFromList builds steps from the first top-level numbered list.
+// An item continues through blank lines, indented lines and fenced code
+// blocks; a heading or an unindented paragraph ends the list.
+func stepsFromList(lines []string) []Step {
+	var (
+		steps []Step
+		title string
+		item  []string
+		open  bool
+		fence string
+	)
+
+	flush := func() {
+		if open {
+			steps = append(steps, buildStep(len(steps)+1, title, item))
+		}
+		item = nil
+	}
+
+	for _, line := range lines {
+		trimmed := strings.TrimSpace(line)
+
+		// Keep fenced blocks intact inside the current item
+		if fence != "" {
+			if strings.HasPrefix(trimmed, fence) {
+				fence = ""
+			}
+			if open {
+				item = append(item, line)
+			}
+			continue
+		}
+		if marker := fenceMarker(trimmed); marker != "" {
+			fence = marker
+			if open {
+				item = append(item, line)
+			}
+			continue
+		}
+
+		if m := numberedItemPattern.FindStringSubmatch(line); m != nil {
+			if n, _ := strconv.Atoi(m[1]); !open && n != 1 {
+				// Lists are expected to start at 1; treat others as prose
+				continue
+			}
+			flush()
+			open = true
+			title = strings.TrimSpace(m[2])
+			continue
+		}
+
+		if !open {
+			continue
+		}
+
+		isIndented := strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")
+		if trimmed == "" || isIndented {
+			item = append(item, line)
+			continue
+		}
+
+		// A heading or an unindented paragraph ends the list
+		break
+	}
+	flush()
+
+	return steps
+}
+
+// buildStep separates prose from fenced code blocks in a step body.
+func buildStep(number int, title string, body []string) Step {
+	step := Step{Number: number, Title: title}
+
+	var (
+		prose  []string
+		code   []string
+		lang   string
+		fence  string
+		indent string
+	)
+
+	for _, line := range body {
+		trimmed := strings.TrimSpace(line)
+
+		if fence == "" {
+			if marker := fenceMarker(trimmed); marker != "" {
+				fence = marker
+				lang = strings.TrimSpace(strings.TrimPrefix(trimmed, marker))
+				if fields := strings.Fields(lang); len(fields) > 0 {
+					lang = fields[0]
+				}
+				indent = line[:len(line)-len(strings.TrimLeft(line, " \t"))]
+				code = nil
+				continue
+			}
+			prose = append(prose, strings.TrimRight(line, " \t"))
+			continue
+		}
+
+		if strings.HasPrefix(trimmed, fence) {
+			step.Code = append(step.Code, CodeBlock{
+				Language: lang,
+				Code:     strings.Join(code, "\n"),
+			})
+			fence = ""
+			continue
+		}
+		code = append(code, strings.TrimPrefix(line, indent))
+	}
+
+	step.Prose = strings.TrimSpace(collapseBlankLines(dedent(prose)))
+	return step
+}
+
+// dedent removes the indentation shared by all non-blank lines.
+func dedent(lines []string) []string {
+	common := -1
+	for _, line := range lines {
+		if line == "" {
+			continue
+		}
+		n := len(line) - len(strings.TrimLeft(line, " \t"))
+		if common < 0 || n < common {
+			common = n
+		}
+	}
+
+	out := make([]string, len(lines))
+	for i, line := range lines {
+		if len(line) >= common && common > 0 {
+			line = line[common:]
+		}
+		out[i] = line
+	}
+	return out
+}
+
+// collapseBlankLines joins lines, folding runs of blank lines into one.
+func collapseBlankLines(lines []string) string {
+	var out []string
+	for _, line := range lines {
+		if line == "" && len(out) > 0 && out[len(out)-1] == "" {
+			continue
+		}
+		out = append(out, line)
+	}
+	return strings.Join(out, "\n")
+}
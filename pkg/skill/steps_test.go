@@ -0,0 +1,62 @@
+package skill
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestExtractSteps(t *testing.T) {
+	parser := NewParser()
+
+	tests := []struct {
+		name     string
+		body     string
+		expected []Step
+	}{
+		{
+			name: "step headings",
+			body: "# Deploy\n\n## Instructions\n\n### Step 1: Analyze\n\nInspect the diff.\n\n```bash\ngit diff --staged\n```\n\n### Step 2 - Commit\n\nWrite the message.\n\n## Examples\n\nNone.",
+			expected: []Step{
+				{Number: 1, Title: "Analyze", Prose: "Inspect the diff.", Code: []CodeBlock{{Language: "bash", Code: "git diff --staged"}}},
+				{Number: 2, Title: "Commit", Prose: "Write the message."},
+			},
+		},
+		{
+			name: "numbered list under instructions",
+			body: "# Tool\n\n## Overview\n\n1. Not a step\n\n## Instructions\n\n1. Stage changes\n   Use git add.\n\n   ```sh\n   git add -A\n   ```\n2. Commit\n\nTrailing paragraph.",
+			expected: []Step{
+				{Number: 1, Title: "Stage changes", Prose: "Use git add.", Code: []CodeBlock{{Language: "sh", Code: "git add -A"}}},
+				{Number: 2, Title: "Commit"},
+			},
+		},
+		{
+			name: "heading inside code block is ignored",
+			body: "## Step 1: Run\n\n```bash\n# Step 2: comment\nmake\n```",
+			expected: []Step{
+				{Number: 1, Title: "Run", Code: []CodeBlock{{Language: "bash", Code: "# Step 2: comment\nmake"}}},
+			},
+		},
+		{
+			name: "code block inside instructions",
+			body: "# Tool\n\n## Overview\n\n1. Not a step\n\n## Instructions\n\n```bash\n# setup\nmake deps\n```\n\n1. Build\n2. Test",
+			expected: []Step{
+				{Number: 1, Title: "Build"},
+				{Number: 2, Title: "Test"},
+			},
+		},
+		{
+			name:     "no steps",
+			body:     "# Notes\n\nJust prose.",
+			expected: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := parser.ExtractSteps(tt.body)
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("ExtractSteps() = %+v, want %+v", result, tt.expected)
+			}
+		})
+	}
+}
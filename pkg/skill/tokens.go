@@ -0,0 +1,47 @@
+package skill
+
+import "unicode/utf8"
+
+// TokenCounter estimates how many model tokens a piece of text consumes.
+// Implementations may wrap a real tokenizer; DefaultTokenCounter is a
+// dependency-free heuristic.
+type TokenCounter interface {
+	CountTokens(text string) int
+}
+
+// TokenCounterFunc adapts a plain function to the TokenCounter interface.
+type TokenCounterFunc func(text string) int
+
+// CountTokens calls f(text).
+func (f TokenCounterFunc) CountTokens(text string) int {
+	return f(text)
+}
+
+// HeuristicTokenCounter estimates tokens without a tokenizer:
+// roughly 4 ASCII characters per token and one token per non-ASCII rune
+// (CJK text tokenizes close to one token per character).
+type HeuristicTokenCounter struct{}
+
+// CountTokens returns the estimated token count of text.
+func (HeuristicTokenCounter) CountTokens(text string) int {
+	if text == "" {
+		return 0
+	}
+
+	ascii, other := 0, 0
+	for i := 0; i < len(text); {
+		if text[i] < utf8.RuneSelf {
+			ascii++
+			i++
+			continue
+		}
+		_, size := utf8.DecodeRuneInString(text[i:])
+		other++
+		i += size
+	}
+
+	return (ascii+3)/4 + other
+}
+
+// DefaultTokenCounter is the token counter used when none is configured.
+var DefaultTokenCounter TokenCounter = HeuristicTokenCounter{}
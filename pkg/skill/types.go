@@ -17,6 +17,34 @@ type Skill struct {
 	// Description describes what the skill does and when to use it
 	Description string `json:"description" yaml:"description"`
 
+	// Version is the skill version (from YAML frontmatter or the directory suffix)
+	Version string `json:"version,omitempty" yaml:"version"`
+
+	// Tags are free-form labels used for search and selection
+	Tags []string `json:"tags,omitempty" yaml:"tags"`
+
+	// Triggers are phrases that indicate the skill should be used
+	Triggers []string `json:"triggers,omitempty" yaml:"triggers"`
+
+	// DependsOn references the skills this skill builds on
+	DependsOn []string `json:"depends_on,omitempty" yaml:"depends-on"`
+
+	// Extends references the base skill whose sections this skill overrides
+	Extends string `json:"extends,omitempty" yaml:"extends"`
+
+	// Deprecated marks a skill that should no longer be used
+	Deprecated bool `json:"deprecated,omitempty" yaml:"deprecated"`
+
+	// ReplacedBy references the skill that replaces a deprecated skill
+	ReplacedBy string `json:"replaced_by,omitempty" yaml:"replaced-by"`
+
+	// AllowedTools lists the tools the skill may use
+	AllowedTools []string `json:"allowed_tools,omitempty" yaml:"allowed-tools"`
+
+	// Author and License describe the skill's origin
+	Author  string `json:"author,omitempty" yaml:"author"`
+	License string `json:"license,omitempty" yaml:"license"`
+
 	// Path is the absolute path to the skill directory
 	Path string `json:"path"`
 
@@ -77,6 +105,9 @@ const (
 
 	// SourcePlugin for plugin-provided skills
 	SourcePlugin SkillSource = "plugin"
+
+	// SourceSession for skills added to a session overlay
+	SourceSession SkillSource = "session"
 )
 
 // Frontmatter represents the YAML frontmatter of a SKILL.md file.
@@ -87,6 +118,12 @@ type Frontmatter struct {
 	// Optional fields
 	AllowedTools []string `yaml:"allowed-tools,omitempty"`
 	Version      string   `yaml:"version,omitempty"`
+	Tags         []string `yaml:"tags,omitempty"`
+	Triggers     []string `yaml:"triggers,omitempty"`
+	DependsOn    []string `yaml:"depends-on,omitempty"`
+	Extends      string   `yaml:"extends,omitempty"`
+	Deprecated   bool     `yaml:"deprecated,omitempty"`
+	ReplacedBy   string   `yaml:"replaced-by,omitempty"`
 	Author       string   `yaml:"author,omitempty"`
 	License      string   `yaml:"license,omitempty"`
 }
@@ -109,12 +146,36 @@ func (f *Frontmatter) Validate() error {
 }
 
 // SkillMetadata is the lightweight metadata loaded at startup.
-// Only name and description are included to minimize context usage.
+// Only name, description and selection hints are included to minimize context usage.
 type SkillMetadata struct {
 	Name        string      `json:"name"`
 	Description string      `json:"description"`
+	Version     string      `json:"version,omitempty"`
+	Tags        []string    `json:"tags,omitempty"`
+	Triggers    []string    `json:"triggers,omitempty"`
+	DependsOn   []string    `json:"depends_on,omitempty"`
+	Extends     string      `json:"extends,omitempty"`
+	Deprecated  bool        `json:"deprecated,omitempty"`
+	ReplacedBy  string      `json:"replaced_by,omitempty"`
 	Source      SkillSource `json:"source"`
 	Path        string      `json:"path"`
+
+	// Stale is set when SKILL.md no longer parses and the registry keeps
+	// serving the last valid version; see Registry.LoadReport
+	Stale bool `json:"stale,omitempty"`
+
+	// modTime is the modification time of SKILL.md when the metadata was
+	// loaded, used to detect edits to the body
+	modTime time.Time
+}
+
+// Ref returns the versioned reference of the skill ("name@version"),
+// or just the name when the skill is unversioned.
+func (m SkillMetadata) Ref() string {
+	if m.Version == "" {
+		return m.Name
+	}
+	return m.Name + "@" + m.Version
 }
 
 // ToMetadata extracts metadata from a full skill.
@@ -122,6 +183,13 @@ func (s *Skill) ToMetadata() SkillMetadata {
 	return SkillMetadata{
 		Name:        s.Name,
 		Description: s.Description,
+		Version:     s.Version,
+		Tags:        s.Tags,
+		Triggers:    s.Triggers,
+		DependsOn:   s.DependsOn,
+		Extends:     s.Extends,
+		Deprecated:  s.Deprecated,
+		ReplacedBy:  s.ReplacedBy,
 		Source:      s.Source,
 		Path:        s.Path,
 	}
@@ -171,4 +239,5 @@ var (
 	ErrSkillNotFound      = &SkillError{Message: "skill not found"}
 	ErrInvalidFrontmatter = &SkillError{Message: "invalid YAML frontmatter"}
 	ErrMissingSkillMD     = &SkillError{Message: "SKILL.md file not found"}
+	ErrVersionNotFound    = &SkillError{Message: "no skill version matches"}
 )
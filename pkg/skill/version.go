@@ -0,0 +1,397 @@
+package skill
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Version is a parsed semantic version (major.minor.patch[-prerelease]).
+// Build metadata (+build) is ignored.
+type Version struct {
+	Major      int
+	Minor      int
+	Patch      int
+	Prerelease string
+}
+
+// ParseVersion parses a semantic version string.
+// A leading "v" is accepted and missing minor/patch components default to 0,
+// so "v1", "1.2" and "1.2.3" are all valid.
+func ParseVersion(s string) (Version, error) {
+	var v Version
+	s = strings.TrimPrefix(strings.TrimSpace(s), "v")
+	if s == "" {
+		return v, fmt.Errorf("empty version")
+	}
+
+	if i := strings.IndexByte(s, '+'); i >= 0 {
+		s = s[:i]
+	}
+	if i := strings.IndexByte(s, '-'); i >= 0 {
+		v.Prerelease = s[i+1:]
+		s = s[:i]
+	}
+
+	parts := strings.Split(s, ".")
+	if len(parts) > 3 {
+		return v, fmt.Errorf("invalid version %q", s)
+	}
+
+	nums := []*int{&v.Major, &v.Minor, &v.Patch}
+	for i, p := range parts {
+		n, err := strconv.Atoi(p)
+		if err != nil || n < 0 {
+			return v, fmt.Errorf("invalid version %q", s)
+		}
+		*nums[i] = n
+	}
+
+	return v, nil
+}
+
+// String returns the canonical form of the version.
+func (v Version) String() string {
+	s := fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+	if v.Prerelease != "" {
+		s += "-" + v.Prerelease
+	}
+	return s
+}
+
+// Compare returns -1, 0 or 1 if v is lower than, equal to or greater than o.
+// A prerelease version is lower than the same version without prerelease.
+func (v Version) Compare(o Version) int {
+	switch {
+	case v.Major != o.Major:
+		return cmpInt(v.Major, o.Major)
+	case v.Minor != o.Minor:
+		return cmpInt(v.Minor, o.Minor)
+	case v.Patch != o.Patch:
+		return cmpInt(v.Patch, o.Patch)
+	}
+
+	switch {
+	case v.Prerelease == o.Prerelease:
+		return 0
+	case v.Prerelease == "":
+		return 1
+	case o.Prerelease == "":
+		return -1
+	}
+	return comparePrerelease(v.Prerelease, o.Prerelease)
+}
+
+// comparePrerelease orders prerelease strings by semver precedence: dot
+// separated identifiers are compared in turn, numerically when both are
+// numbers, and numeric identifiers sort below alphanumeric ones. A shorter
+// list of otherwise equal identifiers sorts first.
+func comparePrerelease(a, b string) int {
+	as, bs := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < len(as) && i < len(bs); i++ {
+		na, errA := strconv.Atoi(as[i])
+		nb, errB := strconv.Atoi(bs[i])
+		var c int
+		switch {
+		case errA == nil && errB == nil:
+			c = cmpInt(na, nb)
+		case errA == nil:
+			c = -1
+		case errB == nil:
+			c = 1
+		default:
+			c = strings.Compare(as[i], bs[i])
+		}
+		if c != 0 {
+			return c
+		}
+	}
+	return cmpInt(len(as), len(bs))
+}
+
+// cmpInt compares two integers.
+func cmpInt(a, b int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// VersionConstraint matches versions against a semver range expression.
+//
+// Supported forms:
+//
+//	1.2.3, =1.2.3      exact version
+//	>1.2, >=1.2, <2, <=2.1, !=1.3.0
+//	^1.2.3             compatible with 1.2.3 (>=1.2.3 <2.0.0)
+//	~1.2.3             patch updates only (>=1.2.3 <1.3.0)
+//	1.x, 1.2.*         wildcards
+//	*                  any version
+//
+// Space- or comma-separated terms must all match; "||" separates alternatives.
+// Prerelease versions only match an alternative with a term naming a
+// prerelease of the same major.minor.patch, so "1.x" does not match
+// "1.2.0-beta" but ">=1.2.0-beta <2" does.
+type VersionConstraint struct {
+	raw  string
+	alts [][]versionTerm
+}
+
+// versionTerm is a single comparison such as ">=1.2.0".
+type versionTerm struct {
+	op string
+	v  Version
+}
+
+// ParseVersionConstraint parses a semver range expression.
+func ParseVersionConstraint(s string) (*VersionConstraint, error) {
+	c := &VersionConstraint{raw: strings.TrimSpace(s)}
+	if c.raw == "" {
+		return nil, fmt.Errorf("empty version constraint")
+	}
+
+	for _, alt := range strings.Split(c.raw, "||") {
+		fields := strings.FieldsFunc(alt, func(r rune) bool {
+			return r == ' ' || r == ','
+		})
+		if len(fields) == 0 {
+			return nil, fmt.Errorf("invalid version constraint %q", s)
+		}
+
+		var terms []versionTerm
+		for _, f := range fields {
+			t, err := parseVersionTerms(f)
+			if err != nil {
+				return nil, fmt.Errorf("invalid version constraint %q: %w", s, err)
+			}
+			terms = append(terms, t...)
+		}
+		c.alts = append(c.alts, terms)
+	}
+
+	return c, nil
+}
+
+// parseVersionTerms expands one constraint field into comparison terms.
+func parseVersionTerms(f string) ([]versionTerm, error) {
+	if f == "*" || f == "x" || f == "X" {
+		return nil, nil
+	}
+
+	for _, op := range []string{">=", "<=", "!=", ">", "<", "=", "^", "~"} {
+		if !strings.HasPrefix(f, op) {
+			continue
+		}
+		v, err := ParseVersion(f[len(op):])
+		if err != nil {
+			return nil, err
+		}
+
+		switch op {
+		case "^":
+			// The leftmost non-zero component may not change; for a zero
+			// major or minor the bound depends on how many components were
+			// given, e.g. ^0 <1.0.0, ^0.0 <0.1.0 and ^0.0.3 <0.0.4
+			n := versionComponents(f[len(op):])
+			upper := Version{Major: v.Major + 1}
+			switch {
+			case v.Major > 0 || n == 1:
+			case v.Minor > 0 || n == 2:
+				upper = Version{Minor: v.Minor + 1}
+			default:
+				upper = Version{Minor: v.Minor, Patch: v.Patch + 1}
+			}
+			return []versionTerm{{">=", v}, {"<", upper}}, nil
+		case "~":
+			return []versionTerm{{">=", v}, {"<", Version{Major: v.Major, Minor: v.Minor + 1}}}, nil
+		default:
+			return []versionTerm{{op, v}}, nil
+		}
+	}
+
+	// Wildcard forms: 1.x, 1.2.*
+	parts := strings.Split(strings.TrimPrefix(f, "v"), ".")
+	for i, p := range parts {
+		if p != "x" && p != "X" && p != "*" {
+			continue
+		}
+		lower, err := ParseVersion(strings.Join(parts[:i], "."))
+		if err != nil {
+			return nil, err
+		}
+		if i == 1 {
+			return []versionTerm{{">=", lower}, {"<", Version{Major: lower.Major + 1}}}, nil
+		}
+		return []versionTerm{{">=", lower}, {"<", Version{Major: lower.Major, Minor: lower.Minor + 1}}}, nil
+	}
+
+	v, err := ParseVersion(f)
+	if err != nil {
+		return nil, err
+	}
+	return []versionTerm{{"=", v}}, nil
+}
+
+// versionComponents returns how many of major, minor and patch a version
+// string gives.
+func versionComponents(s string) int {
+	s, _, _ = strings.Cut(s, "+")
+	s, _, _ = strings.Cut(s, "-")
+	return strings.Count(s, ".") + 1
+}
+
+// Check reports whether v satisfies the constraint.
+func (c *VersionConstraint) Check(v Version) bool {
+	for _, terms := range c.alts {
+		ok := v.Prerelease == "" || allowsPrerelease(terms, v)
+		for _, t := range terms {
+			if !ok || !t.check(v) {
+				ok = false
+				break
+			}
+		}
+		if ok {
+			return true
+		}
+	}
+	return false
+}
+
+// allowsPrerelease reports whether a term names a prerelease of the same
+// major.minor.patch as v.
+func allowsPrerelease(terms []versionTerm, v Version) bool {
+	for _, t := range terms {
+		if t.v.Prerelease != "" && t.v.Major == v.Major && t.v.Minor == v.Minor && t.v.Patch == v.Patch {
+			return true
+		}
+	}
+	return false
+}
+
+// String returns the original constraint expression.
+func (c *VersionConstraint) String() string {
+	return c.raw
+}
+
+// check evaluates a single comparison term.
+func (t versionTerm) check(v Version) bool {
+	cmp := v.Compare(t.v)
+	switch t.op {
+	case "=":
+		return cmp == 0
+	case "!=":
+		return cmp != 0
+	case ">":
+		return cmp > 0
+	case ">=":
+		return cmp >= 0
+	case "<":
+		return cmp < 0
+	case "<=":
+		return cmp <= 0
+	}
+	return false
+}
+
+// SplitSkillRef splits a skill reference of the form "name@version" into
+// its name and version constraint. The constraint is empty for a bare name.
+func SplitSkillRef(ref string) (name, constraint string) {
+	if i := strings.IndexByte(ref, '@'); i >= 0 {
+		return ref[:i], strings.TrimSpace(ref[i+1:])
+	}
+	return ref, ""
+}
+
+// matchVersion reports whether a skill version string satisfies constraint.
+// An exact string match always succeeds, so unversioned or non-semver skills
+// can still be addressed by their literal version.
+func matchVersion(version, constraint string) bool {
+	if constraint == "" || version == constraint {
+		return true
+	}
+
+	c, err := ParseVersionConstraint(constraint)
+	if err != nil {
+		return false
+	}
+	v, err := ParseVersion(version)
+	if err != nil {
+		return false
+	}
+	return c.Check(v)
+}
+
+// compareVersionStrings orders two skill version strings.
+// Unparsable or empty versions sort below any valid semver.
+func compareVersionStrings(a, b string) int {
+	va, errA := ParseVersion(a)
+	vb, errB := ParseVersion(b)
+	switch {
+	case errA != nil && errB != nil:
+		return strings.Compare(a, b)
+	case errA != nil:
+		return -1
+	case errB != nil:
+		return 1
+	default:
+		return va.Compare(vb)
+	}
+}
+
+// sortByVersionDesc sorts metadata entries from the newest version to the oldest.
+func sortByVersionDesc(entries []SkillMetadata) {
+	sort.SliceStable(entries, func(i, j int) bool {
+		return compareVersionStrings(entries[i].Version, entries[j].Version) > 0
+	})
+}
+
+// sortByName sorts metadata entries by skill name, keeping prompts and
+// listings stable across runs.
+func sortByName(entries []SkillMetadata) {
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].Name < entries[j].Name
+	})
+}
+
+// selectVersion returns the newest entry whose version satisfies constraint.
+func selectVersion(entries []SkillMetadata, constraint string) (SkillMetadata, bool) {
+	sorted := make([]SkillMetadata, len(entries))
+	copy(sorted, entries)
+	sortByVersionDesc(sorted)
+
+	for _, m := range sorted {
+		if matchVersion(m.Version, constraint) {
+			return m, true
+		}
+	}
+	return SkillMetadata{}, false
+}
+
+// selectDefault returns the entry a bare skill name or a version pin
+// resolves to: the newest project version satisfying constraint, or the
+// newest global one if no project version does. Project skills thus take
+// precedence over global skills with the same name, whatever their versions.
+func selectDefault(entries []SkillMetadata, constraint string) (SkillMetadata, bool) {
+	var project []SkillMetadata
+	for _, m := range entries {
+		if m.Source == SourceProject {
+			project = append(project, m)
+		}
+	}
+	if m, ok := selectVersion(project, constraint); ok {
+		return m, true
+	}
+	return selectVersion(entries, constraint)
+}
+
+// dirVersion extracts the version suffix from a versioned skill directory
+// name such as "git-commit@1.2.0". It returns "" for unversioned directories.
+func dirVersion(dirName string) string {
+	_, v := SplitSkillRef(dirName)
+	return v
+}
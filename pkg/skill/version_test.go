@@ -0,0 +1,182 @@
+package skill
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestVersionConstraint(t *testing.T) {
+	tests := []struct {
+		constraint string
+		version    string
+		expected   bool
+	}{
+		{"1.2.3", "1.2.3", true},
+		{"=1.2.3", "1.2.4", false},
+		{"^1.2.0", "1.9.9", true},
+		{"^1.2.0", "2.0.0", false},
+		{"^0.2.0", "0.3.0", false},
+		{"~1.2.0", "1.2.9", true},
+		{"~1.2.0", "1.3.0", false},
+		{">=1.0 <2.0", "1.5.0", true},
+		{">=1.0, <2.0", "2.0.0", false},
+		{"1.x", "1.4.2", true},
+		{"1.2.*", "1.3.0", false},
+		{"*", "0.0.1", true},
+		{"<1.0 || >=3.0", "3.1.0", true},
+		{"<1.0 || >=3.0", "2.0.0", false},
+		{"!=1.0.0", "1.0.0", false},
+		{">=1.0.0", "1.0.0-beta", false},
+		{"^0.0.3", "0.0.3", true},
+		{"^0.0.3", "0.0.9", false},
+		{"^0.0", "0.0.9", true},
+		{"^0", "0.9.0", true},
+		{"1.x", "1.2.0-beta", false},
+		{">=1.0 <2.0", "1.5.0-rc.1", false},
+		{"^1.2.0", "2.0.0-alpha", false},
+		{">=1.2.0-beta <2", "1.2.0-rc.1", true},
+		{">=1.2.0-beta <2", "1.3.0-rc.1", false},
+		{">1.0.0-rc.2", "1.0.0-rc.10", true},
+		{">1.0.0-rc.2", "1.0.0-rc.alpha", true},
+		{"<1.0.0-rc.2", "1.0.0-rc.2.1", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.constraint+"/"+tt.version, func(t *testing.T) {
+			c, err := ParseVersionConstraint(tt.constraint)
+			if err != nil {
+				t.Fatalf("ParseVersionConstraint(%q) error: %v", tt.constraint, err)
+			}
+			v, err := ParseVersion(tt.version)
+			if err != nil {
+				t.Fatalf("ParseVersion(%q) error: %v", tt.version, err)
+			}
+			if got := c.Check(v); got != tt.expected {
+				t.Errorf("Check() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestRegistryVersionResolution(t *testing.T) {
+	dir := t.TempDir()
+	for _, v := range []string{"1.0.0", "1.4.0", "2.0.0"} {
+		writeTestSkill(t, filepath.Join(dir, "deploy@"+v), "deploy", "Deploy services version "+v, v)
+	}
+
+	tests := []struct {
+		name     string
+		pins     map[string]string
+		ref      string
+		expected string
+	}{
+		{name: "newest by default", ref: "deploy", expected: "2.0.0"},
+		{name: "exact version", ref: "deploy@1.0.0", expected: "1.0.0"},
+		{name: "semver range", ref: "deploy@^1.0", expected: "1.4.0"},
+		{name: "pinned default", pins: map[string]string{"deploy": "~1.0"}, ref: "deploy", expected: "1.0.0"},
+		{name: "explicit version overrides pin", pins: map[string]string{"deploy": "1.0.0"}, ref: "deploy@2", expected: "2.0.0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			loader := NewLoader(WithGlobalSkillsDir(filepath.Join(dir, "missing")), WithProjectSkillsDir(dir))
+			registry := NewRegistry(loader, WithVersionPins(tt.pins))
+			if err := registry.Initialize(context.Background()); err != nil {
+				t.Fatalf("Initialize() error: %v", err)
+			}
+
+			s, err := registry.Get(context.Background(), tt.ref)
+			if err != nil {
+				t.Fatalf("Get(%q) error: %v", tt.ref, err)
+			}
+			if s.Version != tt.expected {
+				t.Errorf("Get(%q).Version = %q, want %q", tt.ref, s.Version, tt.expected)
+			}
+		})
+	}
+
+	loader := NewLoader(WithGlobalSkillsDir(filepath.Join(dir, "missing")), WithProjectSkillsDir(dir))
+	registry := NewRegistry(loader)
+	if err := registry.Initialize(context.Background()); err != nil {
+		t.Fatalf("Initialize() error: %v", err)
+	}
+	if _, err := registry.Get(context.Background(), "deploy@^3"); err == nil {
+		t.Error("Get(deploy@^3) expected error for unmatched range")
+	}
+	if got := len(registry.Versions("deploy")); got != 3 {
+		t.Errorf("Versions() returned %d entries, want 3", got)
+	}
+	if got := registry.Count(); got != 1 {
+		t.Errorf("Count() = %d, want 1", got)
+	}
+}
+
+func TestProjectVersionPrecedence(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	writeTestSkill(t, filepath.Join(dir, "global", "deploy"), "deploy", "Deploy services globally", "2.0.0")
+	writeTestSkill(t, filepath.Join(dir, "project", "deploy"), "deploy", "Deploy this project", "1.0.0")
+
+	loader := NewLoader(WithGlobalSkillsDir(filepath.Join(dir, "global")), WithProjectSkillsDir(filepath.Join(dir, "project")))
+	registry := NewRegistry(loader)
+	if err := registry.Initialize(ctx); err != nil {
+		t.Fatalf("Initialize() error: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		ref     string
+		version string
+		source  SkillSource
+	}{
+		{name: "bare name prefers project", ref: "deploy", version: "1.0.0", source: SourceProject},
+		{name: "explicit global version", ref: "deploy@2.0.0", version: "2.0.0", source: SourceGlobal},
+		{name: "range picks newest", ref: "deploy@>=1.0.0", version: "2.0.0", source: SourceGlobal},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := registry.Get(ctx, tt.ref)
+			if err != nil {
+				t.Fatalf("Get(%q) error: %v", tt.ref, err)
+			}
+			if s.Version != tt.version || s.Source != tt.source {
+				t.Errorf("Get(%q) = %s from %s, want %s from %s", tt.ref, s.Version, s.Source, tt.version, tt.source)
+			}
+
+			// The loader resolves references like the registry
+			loaded, err := loader.LoadSkill(ctx, tt.ref)
+			if err != nil {
+				t.Fatalf("LoadSkill(%q) error: %v", tt.ref, err)
+			}
+			if loaded.Version != tt.version || loaded.Source != tt.source {
+				t.Errorf("LoadSkill(%q) = %s from %s, want %s from %s", tt.ref, loaded.Version, loaded.Source, tt.version, tt.source)
+			}
+		})
+	}
+
+	if names := promptNames(registry.GenerateSystemPromptSection()); len(names) != 1 {
+		t.Errorf("prompt lists %v, want one deploy entry", names)
+	}
+	if m := registry.GetMetadata(); len(m) != 1 || m[0].Description != "Deploy this project" {
+		t.Errorf("GetMetadata() = %+v, want the project skill", m)
+	}
+}
+
+// writeTestSkill creates a SKILL.md with the given frontmatter in dir.
+func writeTestSkill(t *testing.T, dir, name, description, version string) {
+	t.Helper()
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	content := "---\nname: " + name + "\ndescription: " + description + "\n"
+	if version != "" {
+		content += "version: " + version + "\n"
+	}
+	content += "---\n\n# " + name + "\n\n## Instructions\n\nDo the thing.\n"
+	if err := os.WriteFile(filepath.Join(dir, SkillFileName), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
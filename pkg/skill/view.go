@@ -0,0 +1,256 @@
+package skill
+
+import (
+	"context"
+	"slices"
+	"sync"
+)
+
+// Catalog is the read-only skill lookup API shared by Registry, Snapshot,
+// View and Overlay. Tools and middleware accept a Catalog, so an agent can be given a
+// subset of the registry.
+type Catalog interface {
+	// Resolve returns the metadata of the installed version a reference points to
+	Resolve(ref string) (SkillMetadata, error)
+
+	// Versions returns all installed versions of a skill, newest first
+	Versions(name string) []SkillMetadata
+
+	// Get retrieves a skill, loading it on demand
+	Get(ctx context.Context, name string) (*Skill, error)
+
+	// GetContent retrieves the full content of a skill
+	GetContent(ctx context.Context, name string) (string, error)
+
+	// GetMetadata returns a copy of the metadata of every skill
+	GetMetadata() []SkillMetadata
+
+	// FindMatchingSkill returns the best lexical match for a query, or nil
+	FindMatchingSkill(query string) *SkillMetadata
+
+	// FindMatchingSkills returns the top k lexical matches for a query
+	FindMatchingSkills(query string, k int) []SkillMatch
+
+	// Search ranks skills against a query, semantically if configured
+	Search(ctx context.Context, query string, k int) ([]SkillMatch, error)
+
+	// SearchContent searches skill bodies and reference files
+	SearchContent(ctx context.Context, query string, k int) ([]ContentHit, error)
+
+	// GenerateSystemPromptSection generates the skills section for system prompts
+	GenerateSystemPromptSection() string
+
+	// GenerateSkillsInstructions generates instructions for using skills
+	GenerateSkillsInstructions() string
+
+	// RenderPrompt renders the skills section and instructions for the given tools
+	RenderPrompt(tools []string) Prompt
+
+	// Prerequisites returns the skills a skill transitively depends on,
+	// dependencies first
+	Prerequisites(ref string) ([]SkillMetadata, error)
+
+	// Count returns the number of skills
+	Count() int
+
+	// Names returns all skill names
+	Names() []string
+}
+
+var (
+	_ Catalog = (*Registry)(nil)
+	_ Catalog = (*Snapshot)(nil)
+	_ Catalog = (*View)(nil)
+	_ Catalog = (*Overlay)(nil)
+)
+
+// Filter selects skills for a View. Every non-empty criterion must match;
+// within a criterion, matching any entry is enough.
+type Filter struct {
+	// Names are path.Match globs matched against skill names
+	Names []string
+
+	// Tags selects skills with at least one of the tags (globs allowed)
+	Tags []string
+
+	// Sources selects skills from the given sources
+	Sources []SkillSource
+
+	// Match is an optional custom predicate
+	Match func(SkillMetadata) bool
+}
+
+// Matches reports whether a skill passes the filter.
+func (f Filter) Matches(m SkillMetadata) bool {
+	if len(f.Names) > 0 && !matchAny(f.Names, m.Name) {
+		return false
+	}
+	if len(f.Tags) > 0 && !matchAnyTag(f.Tags, m.Tags) {
+		return false
+	}
+	if len(f.Sources) > 0 && !slices.Contains(f.Sources, m.Source) {
+		return false
+	}
+	return f.Match == nil || f.Match(m)
+}
+
+// Filter returns a snapshot with only the skill versions that pass the
+// filter. A skill whose default version is filtered out falls back to its
+// newest (or pinned) remaining version. The filtered snapshot shares the
+// registry's content cache and never loads skills outside its set.
+func (s *Snapshot) Filter(filter Filter) *Snapshot {
+	filtered := &Snapshot{
+		registry:   s.registry,
+		generation: s.generation,
+		loadedAt:   s.loadedAt,
+		versions:   make(map[string][]SkillMetadata),
+		vectors:    s.vectors,
+		filtered:   true,
+		unfiltered: s,
+		local:      s.local,
+		usage:      s.usage,
+	}
+	if s.unfiltered != nil {
+		filtered.unfiltered = s.unfiltered
+	}
+
+	for name, versions := range s.versions {
+		var kept []SkillMetadata
+		for _, v := range versions {
+			if filter.Matches(v) {
+				kept = append(kept, v)
+			}
+		}
+		if len(kept) > 0 {
+			filtered.versions[name] = kept
+		}
+	}
+
+	// Keep the snapshot's order of skills
+	for _, m := range s.metadata {
+		if versions, ok := filtered.versions[m.Name]; ok {
+			filtered.metadata = append(filtered.metadata, s.registry.defaultVersion(m.Name, versions))
+		}
+	}
+	for _, f := range s.failures {
+		if _, ok := filtered.versions[f.Name]; ok {
+			filtered.failures = append(filtered.failures, f)
+		}
+	}
+	filtered.index = newSearchIndex(filtered.metadata)
+
+	return filtered
+}
+
+// View is a read-only, filtered view of a registry, for example the skill
+// profile of one agent. It follows registry reloads.
+type View struct {
+	registry *Registry
+	filter   Filter
+	usage    usageTracker
+
+	// current is the filtered snapshot of the latest registry generation
+	mu      sync.Mutex
+	current *Snapshot
+}
+
+// View returns a read-only view of the skills that pass the filter.
+func (r *Registry) View(filter Filter) *View {
+	return &View{registry: r, filter: filter}
+}
+
+// Snapshot returns the filtered snapshot of the registry's current
+// generation, building it on first use.
+func (v *View) Snapshot() *Snapshot {
+	base := v.registry.Snapshot()
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	if v.current == nil || v.current.generation != base.generation {
+		v.current = base.Filter(v.filter)
+		v.current.usage = &v.usage
+		v.usage.retain(v.current.versions)
+	}
+	return v.current
+}
+
+// Resolve returns the metadata of the installed version a reference points to.
+func (v *View) Resolve(ref string) (SkillMetadata, error) {
+	return v.Snapshot().Resolve(ref)
+}
+
+// Versions returns all visible versions of a skill, newest first.
+func (v *View) Versions(name string) []SkillMetadata {
+	return v.Snapshot().Versions(name)
+}
+
+// Get retrieves a visible skill, loading it on demand if needed.
+func (v *View) Get(ctx context.Context, name string) (*Skill, error) {
+	return v.Snapshot().Get(ctx, name)
+}
+
+// GetContent retrieves the full content of a visible skill.
+func (v *View) GetContent(ctx context.Context, name string) (string, error) {
+	return v.Snapshot().GetContent(ctx, name)
+}
+
+// GetMetadata returns a copy of the metadata of all visible skills.
+func (v *View) GetMetadata() []SkillMetadata {
+	return v.Snapshot().GetMetadata()
+}
+
+// FindMatchingSkill finds a visible skill that matches the given query.
+func (v *View) FindMatchingSkill(query string) *SkillMetadata {
+	return v.Snapshot().FindMatchingSkill(query)
+}
+
+// FindMatchingSkills ranks visible skills against a query using BM25.
+func (v *View) FindMatchingSkills(query string, k int) []SkillMatch {
+	return v.Snapshot().FindMatchingSkills(query, k)
+}
+
+// Search ranks visible skills against a natural-language query.
+func (v *View) Search(ctx context.Context, query string, k int) ([]SkillMatch, error) {
+	return v.Snapshot().Search(ctx, query, k)
+}
+
+// SearchContent searches the bodies and reference files of visible skills.
+func (v *View) SearchContent(ctx context.Context, query string, k int) ([]ContentHit, error) {
+	return v.Snapshot().SearchContent(ctx, query, k)
+}
+
+// GenerateSystemPromptSection generates the skills section for system prompts.
+func (v *View) GenerateSystemPromptSection() string {
+	return v.Snapshot().GenerateSystemPromptSection()
+}
+
+// GenerateSkillsInstructions generates instructions for using skills.
+func (v *View) GenerateSkillsInstructions() string {
+	return v.Snapshot().GenerateSkillsInstructions()
+}
+
+// RenderPrompt renders the prompt for the visible skills.
+func (v *View) RenderPrompt(tools []string) Prompt {
+	return v.Snapshot().RenderPrompt(tools)
+}
+
+// Dependencies returns the dependency graph of the visible skills.
+func (v *View) Dependencies() *DependencyGraph {
+	return v.Snapshot().Dependencies()
+}
+
+// Prerequisites returns the visible skills a skill transitively depends on.
+func (v *View) Prerequisites(ref string) ([]SkillMetadata, error) {
+	return v.Snapshot().Prerequisites(ref)
+}
+
+// Count returns the number of visible skills.
+func (v *View) Count() int {
+	return v.Snapshot().Count()
+}
+
+// Names returns all visible skill names.
+func (v *View) Names() []string {
+	return v.Snapshot().Names()
+}
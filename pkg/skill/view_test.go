@@ -0,0 +1,88 @@
+package skill
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestRegistryView(t *testing.T) {
+	ctx := context.Background()
+	globalDir := t.TempDir()
+	projectDir := t.TempDir()
+	writeTestSkill(t, filepath.Join(projectDir, "deploy"), "deploy", "Deploy services", "")
+	writeTestSkill(t, filepath.Join(projectDir, "git-commit"), "git-commit", "Write commit messages", "")
+	writeTestSkill(t, filepath.Join(globalDir, "git-review"), "git-review", "Review pull requests", "")
+	if err := os.MkdirAll(filepath.Join(projectDir, "support"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	content := "---\nname: support\ndescription: Answer support tickets\ntags: [support, review]\n---\n\n# support\n"
+	if err := os.WriteFile(filepath.Join(projectDir, "support", SkillFileName), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	registry := NewRegistry(NewLoader(WithGlobalSkillsDir(globalDir), WithProjectSkillsDir(projectDir)))
+	if err := registry.Initialize(ctx); err != nil {
+		t.Fatalf("Initialize() error: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		filter   Filter
+		expected []string
+	}{
+		{name: "empty filter", filter: Filter{}, expected: []string{"deploy", "git-commit", "git-review", "support"}},
+		{name: "name glob", filter: Filter{Names: []string{"git-*"}}, expected: []string{"git-commit", "git-review"}},
+		{name: "tag", filter: Filter{Tags: []string{"review"}}, expected: []string{"support"}},
+		{name: "source", filter: Filter{Sources: []SkillSource{SourceGlobal}}, expected: []string{"git-review"}},
+		{name: "criteria combine", filter: Filter{Names: []string{"git-*"}, Sources: []SkillSource{SourceProject}}, expected: []string{"git-commit"}},
+		{
+			name:     "predicate",
+			filter:   Filter{Match: func(m SkillMetadata) bool { return strings.Contains(m.Description, "Deploy") }},
+			expected: []string{"deploy"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			view := registry.View(tt.filter)
+
+			names := view.Names()
+			slices.Sort(names)
+			if !slices.Equal(names, tt.expected) {
+				t.Errorf("Names() = %v, want %v", names, tt.expected)
+			}
+			if view.Count() != len(tt.expected) {
+				t.Errorf("Count() = %d, want %d", view.Count(), len(tt.expected))
+			}
+			for _, m := range registry.GetMetadata() {
+				visible := slices.Contains(tt.expected, m.Name)
+				if _, err := view.Get(ctx, m.Name); (err == nil) != visible {
+					t.Errorf("Get(%s) error = %v, visible %v", m.Name, err, visible)
+				}
+				if inPrompt := strings.Contains(view.GenerateSystemPromptSection(), "<name>\n"+m.Name+"\n"); inPrompt != visible {
+					t.Errorf("prompt contains %s = %v, want %v", m.Name, inPrompt, visible)
+				}
+			}
+		})
+	}
+
+	// Views follow reloads
+	view := registry.View(Filter{Names: []string{"git-*"}})
+	if match := view.FindMatchingSkill("deploy services"); match != nil {
+		t.Errorf("FindMatchingSkill() = %s, want no match outside the view", match.Name)
+	}
+	writeTestSkill(t, filepath.Join(projectDir, "git-rebase"), "git-rebase", "Rebase branches", "")
+	if err := registry.Reload(ctx); err != nil {
+		t.Fatalf("Reload() error: %v", err)
+	}
+	if got := view.Names(); !slices.Contains(got, "git-rebase") {
+		t.Errorf("Names() after reload = %v, want git-rebase", got)
+	}
+	if view.Snapshot().Generation() != registry.Snapshot().Generation() {
+		t.Error("view snapshot is behind the registry")
+	}
+}
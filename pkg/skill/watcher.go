@@ -3,24 +3,58 @@ package skill
 import (
 	"context"
 	"fmt"
+	"log/slog"
 	"os"
 	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/fsnotify/fsnotify"
 )
 
+// WatchMode selects how the Watcher detects changes.
+type WatchMode string
+
+const (
+	// WatchModeAuto uses fsnotify and falls back to polling when fsnotify
+	// cannot be initialized or a directory cannot be watched
+	WatchModeAuto WatchMode = "auto"
+
+	// WatchModeNotify uses fsnotify only
+	WatchModeNotify WatchMode = "fsnotify"
+
+	// WatchModePoll periodically scans mtimes and hashes, for filesystems
+	// that do not deliver change notifications (NFS, FUSE, some containers)
+	WatchModePoll WatchMode = "poll"
+)
+
 // Watcher monitors skill directories for changes and triggers reloads.
 type Watcher struct {
-	watcher  *fsnotify.Watcher
-	registry *Registry
-	dirs     []string
-	debounce time.Duration
-	stopCh   chan struct{}
-	doneCh   chan struct{}
-	mu       sync.Mutex
-	running  bool
+	watcher      *fsnotify.Watcher
+	registry     *Registry
+	dirs         []string
+	debounce     time.Duration
+	mode         WatchMode
+	pollInterval time.Duration
+	logger       *slog.Logger
+	stopCh       chan struct{}
+	doneCh       chan struct{}
+	mu           sync.Mutex
+	running      bool
+
+	// snapshot is the last polled file state. Only touched by Start and run.
+	snapshot map[string]fileState
+
+	// missing maps roots that do not exist yet to the ancestor directory
+	// watched for their creation. Only touched by Start and run.
+	missing map[string]string
+
+	// files are watched files outside the roots, such as the skills
+	// config; a change to one triggers a full reload
+	files []string
 }
 
 // WatcherOption configures the Watcher.
@@ -34,29 +68,87 @@ func WithDebounce(d time.Duration) WatcherOption {
 	}
 }
 
-// NewWatcher creates a new file system watcher for skill directories.
-func NewWatcher(registry *Registry, dirs []string, opts ...WatcherOption) (*Watcher, error) {
-	fsWatcher, err := fsnotify.NewWatcher()
-	if err != nil {
-		return nil, fmt.Errorf("failed to create fsnotify watcher: %w", err)
+// WithWatchMode selects fsnotify, polling, or automatic fallback.
+// Default: WatchModeAuto
+func WithWatchMode(mode WatchMode) WatcherOption {
+	return func(w *Watcher) {
+		w.mode = mode
+	}
+}
+
+// WithPollInterval sets how often the polling mode scans for changes.
+// Default: 2s
+func WithPollInterval(d time.Duration) WatcherOption {
+	return func(w *Watcher) {
+		w.pollInterval = d
 	}
+}
 
+// watchFiles adds files outside the watched roots whose changes trigger a
+// full reload. Their directories are watched, so files may be created or
+// replaced later.
+func watchFiles(files ...string) WatcherOption {
+	return func(w *Watcher) {
+		for _, f := range files {
+			if f != "" {
+				w.files = append(w.files, filepath.Clean(expandPath(f)))
+			}
+		}
+	}
+}
+
+// NewWatcher creates a new file system watcher for skill directories.
+func NewWatcher(registry *Registry, dirs []string, opts ...WatcherOption) (*Watcher, error) {
 	w := &Watcher{
-		watcher:  fsWatcher,
-		registry: registry,
-		dirs:     dirs,
-		debounce: 100 * time.Millisecond,
-		stopCh:   make(chan struct{}),
-		doneCh:   make(chan struct{}),
+		registry:     registry,
+		dirs:         make([]string, len(dirs)),
+		debounce:     100 * time.Millisecond,
+		mode:         WatchModeAuto,
+		pollInterval: 2 * time.Second,
+		stopCh:       make(chan struct{}),
+		doneCh:       make(chan struct{}),
+		missing:      make(map[string]string),
+		logger:       discardLogger,
+	}
+	if registry != nil {
+		w.logger = registry.logger
+	}
+	for i, dir := range dirs {
+		w.dirs[i] = filepath.Clean(expandPath(dir))
 	}
 
 	for _, opt := range opts {
 		opt(w)
 	}
 
+	switch w.mode {
+	case WatchModeAuto, WatchModeNotify:
+		fsWatcher, err := fsnotify.NewWatcher()
+		if err != nil {
+			if w.mode == WatchModeNotify {
+				return nil, fmt.Errorf("failed to create fsnotify watcher: %w", err)
+			}
+			w.logger.Warn("fsnotify unavailable, polling for changes", slog.Any("error", err))
+			w.mode = WatchModePoll
+			break
+		}
+		w.watcher = fsWatcher
+	case WatchModePoll:
+	default:
+		return nil, fmt.Errorf("unknown watch mode: %q", w.mode)
+	}
+
 	return w, nil
 }
 
+// Mode returns the watch mode in effect. In WatchModeAuto it reports
+// WatchModePoll once the watcher has fallen back to polling.
+func (w *Watcher) Mode() WatchMode {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.mode
+}
+
 // Start begins watching the configured directories.
 // It runs in a background goroutine and returns immediately.
 func (w *Watcher) Start(ctx context.Context) error {
@@ -68,13 +160,12 @@ func (w *Watcher) Start(ctx context.Context) error {
 	w.running = true
 	w.mu.Unlock()
 
-	// Add directories to watch
-	for _, dir := range w.dirs {
-		expandedDir := expandPath(dir)
-		if err := w.addDirRecursive(expandedDir); err != nil {
-			// Log but don't fail - directory might not exist yet
-			fmt.Fprintf(os.Stderr, "Warning: could not watch %s: %v\n", dir, err)
-		}
+	if w.watcher != nil {
+		w.startNotify()
+	}
+	if w.watcher == nil {
+		// Record the baseline that polls are compared against
+		w.snapshot = w.scan()
 	}
 
 	go w.run(ctx)
@@ -82,6 +173,44 @@ func (w *Watcher) Start(ctx context.Context) error {
 	return nil
 }
 
+// startNotify adds the fsnotify watches. Roots that do not exist yet are
+// picked up once created by watching their nearest existing ancestor.
+// In WatchModeAuto a failure to watch a directory switches to polling.
+func (w *Watcher) startNotify() {
+	for _, file := range w.files {
+		dir := filepath.Dir(file)
+		if _, err := os.Stat(dir); os.IsNotExist(err) {
+			w.watchAncestor(dir)
+			continue
+		}
+		if err := w.watcher.Add(dir); err != nil {
+			w.logger.Warn("could not watch directory", slog.String(logKeyPath, dir), slog.Any("error", err))
+		}
+	}
+	for _, dir := range w.dirs {
+		if _, err := os.Stat(dir); os.IsNotExist(err) {
+			w.watchAncestor(dir)
+			continue
+		}
+		err := w.addDirRecursive(dir)
+		if err == nil {
+			continue
+		}
+		if w.mode == WatchModeAuto {
+			w.logger.Warn("could not watch directory, polling for changes",
+				slog.String(logKeyPath, dir), slog.Any("error", err))
+			w.watcher.Close()
+			w.mu.Lock()
+			w.watcher = nil
+			w.mode = WatchModePoll
+			w.mu.Unlock()
+			return
+		}
+		// Log but don't fail
+		w.logger.Warn("could not watch directory", slog.String(logKeyPath, dir), slog.Any("error", err))
+	}
+}
+
 // addDirRecursive adds a directory and its subdirectories to the watcher.
 func (w *Watcher) addDirRecursive(dir string) error {
 	return filepath.WalkDir(dir, func(path string, d os.DirEntry, err error) error {
@@ -97,6 +226,93 @@ func (w *Watcher) addDirRecursive(dir string) error {
 	})
 }
 
+// watchAncestor watches the nearest existing ancestor of a missing root.
+func (w *Watcher) watchAncestor(root string) {
+	ancestor := filepath.Dir(root)
+	for {
+		if _, err := os.Stat(ancestor); err == nil {
+			break
+		}
+		parent := filepath.Dir(ancestor)
+		if parent == ancestor {
+			return
+		}
+		ancestor = parent
+	}
+
+	if err := w.watcher.Add(ancestor); err != nil {
+		w.logger.Warn("could not watch directory", slog.String(logKeyPath, ancestor), slog.Any("error", err))
+		return
+	}
+	w.missing[root] = ancestor
+}
+
+// unwatchAncestor stops watching an ancestor once no missing root needs it,
+// unless the ancestor lies inside a watched root or holds a watched file.
+func (w *Watcher) unwatchAncestor(ancestor string) {
+	for _, a := range w.missing {
+		if a == ancestor {
+			return
+		}
+	}
+	for _, file := range w.files {
+		if filepath.Dir(file) == ancestor {
+			return
+		}
+	}
+	for _, root := range w.dirs {
+		if isWithin(root, ancestor) {
+			if _, missing := w.missing[root]; !missing {
+				return
+			}
+		}
+	}
+	_ = w.watcher.Remove(ancestor)
+}
+
+// rootCreated handles a directory created on the way to a missing root.
+// It reports whether a root now exists and must be scanned.
+func (w *Watcher) rootCreated(path string) bool {
+	created := false
+	for root, ancestor := range w.missing {
+		if !isWithin(path, root) {
+			continue
+		}
+		delete(w.missing, root)
+		if _, err := os.Stat(root); err != nil {
+			// An intermediate directory appeared; move the watch closer
+			w.watchAncestor(root)
+		}
+		// Check again in case the root was created before the new watch
+		if _, err := os.Stat(root); err == nil {
+			if a, ok := w.missing[root]; ok {
+				delete(w.missing, root)
+				w.unwatchAncestor(a)
+			}
+			if err := w.addDirRecursive(root); err != nil {
+				w.logger.Warn("could not watch directory", slog.String(logKeyPath, root), slog.Any("error", err))
+			}
+			created = true
+		}
+		w.unwatchAncestor(ancestor)
+	}
+	return created
+}
+
+// removeWatches drops the watches on a deleted directory and its subdirectories.
+func (w *Watcher) removeWatches(dir string) {
+	for _, path := range w.watcher.WatchList() {
+		if isWithin(dir, path) {
+			_ = w.watcher.Remove(path)
+		}
+	}
+}
+
+// isWithin reports whether path is dir or lies below it.
+func isWithin(dir, path string) bool {
+	return path == dir || strings.HasPrefix(path, dir+string(filepath.Separator))
+}
+
 // run is the main event loop for the watcher.
 func (w *Watcher) run(ctx context.Context) {
 	defer close(w.doneCh)
@@ -104,8 +320,37 @@ func (w *Watcher) run(ctx context.Context) {
 	var (
 		timer   *time.Timer
 		timerCh <-chan time.Time
-		pending bool
+		dirty   = make(map[string]bool)
+		full    bool
+
+		// Exactly one change source is active: fsnotify or the poll ticker
+		events <-chan fsnotify.Event
+		errs   <-chan error
+		pollCh <-chan time.Time
 	)
+	if w.watcher != nil {
+		events, errs = w.watcher.Events, w.watcher.Errors
+	} else {
+		ticker := time.NewTicker(w.pollInterval)
+		defer ticker.Stop()
+		pollCh = ticker.C
+	}
+
+	// schedule (re)starts the debounce timer
+	schedule := func() {
+		if timer == nil {
+			timer = time.NewTimer(w.debounce)
+			timerCh = timer.C
+			return
+		}
+		if !timer.Stop() {
+			select {
+			case <-timer.C:
+			default:
+			}
+		}
+		timer.Reset(w.debounce)
+	}
 
 	for {
 		select {
@@ -117,61 +362,143 @@ func (w *Watcher) run(ctx context.Context) {
 			w.cleanup(timer)
 			return
 
-		case event, ok := <-w.watcher.Events:
+		case event, ok := <-events:
 			if !ok {
 				return
 			}
 
-			// Only react to SKILL.md changes
-			if filepath.Base(event.Name) != SkillFileName {
-				// But watch for new directories
-				if event.Has(fsnotify.Create) {
-					if info, err := os.Stat(event.Name); err == nil && info.IsDir() {
-						_ = w.watcher.Add(event.Name)
-					}
-				}
+			removed := event.Has(fsnotify.Remove) || event.Has(fsnotify.Rename)
+
+			// The config changed: skills may have been enabled or disabled
+			if w.isWatchedFile(event.Name) {
+				full = true
+				schedule()
+				continue
+			}
+
+			// A missing root (or a directory leading to it) was created
+			if event.Has(fsnotify.Create) && len(w.missing) > 0 && w.rootCreated(event.Name) {
+				full = true
+				schedule()
 				continue
 			}
 
-			// Debounce: reset timer on each event
-			if timer == nil {
-				timer = time.NewTimer(w.debounce)
-				timerCh = timer.C
-			} else {
-				if !timer.Stop() {
-					select {
-					case <-timer.C:
-					default:
-					}
+			// A root was deleted: drop its skills and wait for it to return
+			if removed && w.isRoot(event.Name) {
+				w.removeWatches(event.Name)
+				w.watchAncestor(event.Name)
+				full = true
+				schedule()
+				continue
+			}
+
+			dir, ok := w.skillDirFor(event.Name)
+			if !ok {
+				continue
+			}
+
+			// Watch new directories, including trees moved into place,
+			// and forget deleted ones
+			if event.Has(fsnotify.Create) {
+				if info, err := os.Stat(event.Name); err == nil && info.IsDir() {
+					_ = w.addDirRecursive(event.Name)
 				}
-				timer.Reset(w.debounce)
 			}
-			pending = true
+			if removed {
+				w.removeWatches(event.Name)
+			}
 
-		case err, ok := <-w.watcher.Errors:
+			// Any change inside a skill directory refreshes that skill,
+			// so edits to scripts/ and references/ update Skill.Files
+			w.logger.Debug("skill directory changed",
+				slog.String(logKeyPath, event.Name), slog.String("op", event.Op.String()))
+			dirty[dir] = true
+			schedule()
+
+		case err, ok := <-errs:
 			if !ok {
 				return
 			}
-			fmt.Fprintf(os.Stderr, "Watcher error: %v\n", err)
+			// Events may have been lost (e.g. fsnotify.ErrEventOverflow),
+			// so fall back to a full rescan
+			w.logger.Warn("watcher error, rescanning", slog.Any("error", err))
+			full = true
+			schedule()
 
-		case <-timerCh:
-			if pending {
-				w.triggerReload(ctx)
-				pending = false
+		case <-pollCh:
+			dirs, rescan := w.poll()
+			for _, dir := range dirs {
+				dirty[dir] = true
+			}
+			if rescan {
+				full = true
+			}
+			if len(dirs) > 0 || rescan {
+				schedule()
 			}
+
+		case <-timerCh:
+			w.triggerReload(ctx, dirty, full)
+			dirty = make(map[string]bool)
+			full = false
 			timer = nil
 			timerCh = nil
 		}
 	}
 }
 
-// triggerReload reloads the registry.
-func (w *Watcher) triggerReload(ctx context.Context) {
-	fmt.Println("🔄 Skills changed, reloading...")
-	if err := w.registry.Reload(ctx); err != nil {
-		fmt.Fprintf(os.Stderr, "Failed to reload skills: %v\n", err)
-	} else {
-		fmt.Printf("✅ Reloaded %d skills\n", w.registry.Count())
+// isRoot reports whether path is one of the watched skills directories.
+func (w *Watcher) isRoot(path string) bool {
+	for _, root := range w.dirs {
+		if path == root {
+			return true
+		}
+	}
+	return false
+}
+
+// isWatchedFile reports whether path is one of the watched files.
+func (w *Watcher) isWatchedFile(path string) bool {
+	return slices.Contains(w.files, path)
+}
+
+// skillDirFor maps a path to the skill directory containing it: the first
+// path element below a watched root. Roots themselves are not skill dirs.
+func (w *Watcher) skillDirFor(path string) (string, bool) {
+	for _, root := range w.dirs {
+		rel, err := filepath.Rel(root, path)
+		if err != nil || rel == "." || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			continue
+		}
+		first, _, _ := strings.Cut(rel, string(filepath.Separator))
+		return filepath.Join(root, first), true
+	}
+	return "", false
+}
+
+// triggerReload refreshes the changed skill directories, or rescans
+// everything when full is set or an incremental reload fails. Changes and
+// failures are reported to registry subscribers; see Registry.Subscribe.
+func (w *Watcher) triggerReload(ctx context.Context, dirty map[string]bool, full bool) {
+	if !full {
+		dirs := make([]string, 0, len(dirty))
+		for dir := range dirty {
+			dirs = append(dirs, dir)
+		}
+		sort.Strings(dirs)
+
+		for _, dir := range dirs {
+			if err := w.registry.ReloadSkill(ctx, dir); err != nil {
+				w.logger.Warn("incremental reload failed, rescanning",
+					slog.String(logKeyPath, dir), slog.Any("error", err))
+				full = true
+				break
+			}
+		}
+	}
+
+	if full {
+		_ = w.registry.Reload(ctx)
 	}
 }
 
@@ -180,7 +507,9 @@ func (w *Watcher) cleanup(timer *time.Timer) {
 	if timer != nil {
 		timer.Stop()
 	}
-	w.watcher.Close()
+	if w.watcher != nil {
+		w.watcher.Close()
+	}
 }
 
 // Stop gracefully stops the watcher.
@@ -0,0 +1,276 @@
+package skill
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestWatcherSkillDirFor(t *testing.T) {
+	root := t.TempDir()
+	w, err := NewWatcher(nil, []string{root})
+	if err != nil {
+		t.Fatalf("NewWatcher() error: %v", err)
+	}
+	defer w.watcher.Close()
+
+	tests := []struct {
+		name     string
+		path     string
+		expected string
+	}{
+		{name: "SKILL.md", path: filepath.Join(root, "deploy", SkillFileName), expected: filepath.Join(root, "deploy")},
+		{name: "skill directory", path: filepath.Join(root, "deploy"), expected: filepath.Join(root, "deploy")},
+		{name: "bundled script", path: filepath.Join(root, "deploy", "scripts", "run.sh"), expected: filepath.Join(root, "deploy")},
+		{name: "root itself", path: root},
+		{name: "outside root", path: filepath.Join(filepath.Dir(root), "other", "deploy")},
+		{name: "sibling with common prefix", path: root + "-old" + string(filepath.Separator) + "deploy"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := w.skillDirFor(tt.path)
+			if ok != (tt.expected != "") || got != tt.expected {
+				t.Errorf("skillDirFor(%q) = %q, %v; want %q", tt.path, got, ok, tt.expected)
+			}
+		})
+	}
+}
+
+func TestWatcherPoll(t *testing.T) {
+	root := t.TempDir()
+	writeTestSkill(t, filepath.Join(root, "deploy"), "deploy", "Deploy services", "")
+	writeTestSkill(t, filepath.Join(root, "lint"), "lint", "Lint code", "")
+
+	w, err := NewWatcher(nil, []string{root}, WithWatchMode(WatchModePoll))
+	if err != nil {
+		t.Fatalf("NewWatcher() error: %v", err)
+	}
+	if w.Mode() != WatchModePoll {
+		t.Fatalf("Mode() = %q, want %q", w.Mode(), WatchModePoll)
+	}
+	w.snapshot = w.scan()
+
+	skillMD := filepath.Join(root, "deploy", SkillFileName)
+	info, err := os.Stat(skillMD)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		change   func()
+		expected []string
+		rescan   bool
+	}{
+		{name: "no change", change: func() {}},
+		{
+			// Same size and mtime, as on filesystems with coarse timestamps
+			name: "rewrite detected by hash",
+			change: func() {
+				writeTestSkill(t, filepath.Join(root, "deploy"), "deploy", "Deploy servicez", "")
+				if err := os.Chtimes(skillMD, info.ModTime(), info.ModTime()); err != nil {
+					t.Fatal(err)
+				}
+			},
+			expected: []string{filepath.Join(root, "deploy")},
+		},
+		{
+			name: "bundled file added",
+			change: func() {
+				if err := os.MkdirAll(filepath.Join(root, "lint", "scripts"), 0755); err != nil {
+					t.Fatal(err)
+				}
+				if err := os.WriteFile(filepath.Join(root, "lint", "scripts", "run.sh"), []byte("#!/bin/sh\n"), 0755); err != nil {
+					t.Fatal(err)
+				}
+			},
+			expected: []string{filepath.Join(root, "lint")},
+		},
+		{
+			name:     "skill removed",
+			change:   func() { _ = os.RemoveAll(filepath.Join(root, "deploy")) },
+			expected: []string{filepath.Join(root, "deploy")},
+		},
+		{
+			name:     "root removed",
+			change:   func() { _ = os.RemoveAll(root) },
+			expected: []string{filepath.Join(root, "lint")},
+			rescan:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.change()
+			dirs, rescan := w.poll()
+			if !slices.Equal(dirs, tt.expected) || rescan != tt.rescan {
+				t.Errorf("poll() = %v, %v; want %v, %v", dirs, rescan, tt.expected, tt.rescan)
+			}
+		})
+	}
+}
+
+func TestRegistryPollWatcher(t *testing.T) {
+	root := t.TempDir()
+	registry := NewRegistry(
+		NewLoader(WithGlobalSkillsDir(filepath.Join(root, "missing")), WithProjectSkillsDir(root)),
+		WithAutoWatch(true),
+		WithWatcherOptions(WithWatchMode(WatchModePoll), WithPollInterval(10*time.Millisecond), WithDebounce(10*time.Millisecond)),
+	)
+	if err := registry.Initialize(context.Background()); err != nil {
+		t.Fatalf("Initialize() error: %v", err)
+	}
+	defer registry.StopWatching()
+
+	events := make(chan Event, 1)
+	registry.Subscribe(func(e Event) {
+		events <- e
+	})
+
+	writeTestSkill(t, filepath.Join(root, "deploy"), "deploy", "Deploy services", "")
+
+	select {
+	case e := <-events:
+		if e.Type != EventSkillAdded || e.Name != "deploy" {
+			t.Errorf("event = %s %s, want %s deploy", e.Type, e.Name, EventSkillAdded)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for poll watcher to add the skill")
+	}
+}
+
+func TestWatcherLateRoot(t *testing.T) {
+	dir := t.TempDir()
+	root := filepath.Join(dir, "project", ".eino", "skills")
+	registry := NewRegistry(
+		NewLoader(WithGlobalSkillsDir(filepath.Join(dir, "missing")), WithProjectSkillsDir(root)),
+		WithAutoWatch(true),
+		WithWatcherOptions(WithWatchMode(WatchModeNotify), WithDebounce(10*time.Millisecond)),
+	)
+	if err := registry.Initialize(context.Background()); err != nil {
+		t.Fatalf("Initialize() error: %v", err)
+	}
+	defer registry.StopWatching()
+
+	events := make(chan Event, 4)
+	registry.Subscribe(func(e Event) {
+		events <- e
+	})
+	waitAdded := func(name string) {
+		t.Helper()
+		select {
+		case e := <-events:
+			if e.Type != EventSkillAdded || e.Name != name {
+				t.Errorf("event = %s %s, want %s %s", e.Type, e.Name, EventSkillAdded, name)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("timed out waiting for %s to be added", name)
+		}
+	}
+
+	// The root and its parents are created after the watcher started
+	writeTestSkill(t, filepath.Join(root, "deploy"), "deploy", "Deploy services", "")
+	waitAdded("deploy")
+
+	// The new root is watched like any other
+	writeTestSkill(t, filepath.Join(root, "lint"), "lint", "Lint code", "")
+	waitAdded("lint")
+}
+
+func TestWatcherRemovesDeletedDirs(t *testing.T) {
+	root := t.TempDir()
+	writeTestSkill(t, filepath.Join(root, "deploy"), "deploy", "Deploy services", "")
+	scripts := filepath.Join(root, "deploy", "scripts")
+	if err := os.MkdirAll(scripts, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	registry := NewRegistry(NewLoader(WithGlobalSkillsDir(filepath.Join(root, "missing")), WithProjectSkillsDir(root)))
+	if err := registry.Initialize(context.Background()); err != nil {
+		t.Fatalf("Initialize() error: %v", err)
+	}
+	w, err := NewWatcher(registry, []string{root}, WithWatchMode(WatchModeNotify), WithDebounce(10*time.Millisecond))
+	if err != nil {
+		t.Fatalf("NewWatcher() error: %v", err)
+	}
+	if err := w.Start(context.Background()); err != nil {
+		t.Fatalf("Start() error: %v", err)
+	}
+	defer w.Stop()
+
+	watched := func(dir string) []string {
+		return slices.DeleteFunc(w.watcher.WatchList(), func(path string) bool {
+			return !isWithin(dir, path)
+		})
+	}
+	if got := watched(filepath.Join(root, "deploy")); len(got) != 2 {
+		t.Fatalf("watches = %v, want the skill directory and scripts/", got)
+	}
+
+	if err := os.RemoveAll(filepath.Join(root, "deploy")); err != nil {
+		t.Fatal(err)
+	}
+	deadline := time.Now().Add(5 * time.Second)
+	for len(watched(filepath.Join(root, "deploy"))) > 0 {
+		if time.Now().After(deadline) {
+			t.Fatalf("watches on deleted directory remain: %v", watched(filepath.Join(root, "deploy")))
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if got := watched(root); !slices.Equal(got, []string{root}) {
+		t.Errorf("watches = %v, want only the root", got)
+	}
+}
+
+func TestWatcherConfigReload(t *testing.T) {
+	tests := []struct {
+		name string
+		mode WatchMode
+	}{
+		{name: "fsnotify", mode: WatchModeNotify},
+		{name: "poll", mode: WatchModePoll},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := t.TempDir()
+			projectDir := filepath.Join(root, "skills")
+			writeTestSkill(t, filepath.Join(projectDir, "deploy"), "deploy", "Deploy services", "")
+			writeTestSkill(t, filepath.Join(projectDir, "noisy"), "noisy", "Noisy skill", "")
+
+			registry := NewRegistry(
+				NewLoader(WithGlobalSkillsDir(filepath.Join(root, "missing")), WithProjectSkillsDir(projectDir)),
+				WithAutoWatch(true),
+				WithWatcherOptions(WithWatchMode(tt.mode), WithPollInterval(10*time.Millisecond), WithDebounce(10*time.Millisecond)),
+			)
+			if err := registry.Initialize(context.Background()); err != nil {
+				t.Fatalf("Initialize() error: %v", err)
+			}
+			defer registry.StopWatching()
+
+			events := make(chan Event, 4)
+			registry.Subscribe(func(e Event) {
+				events <- e
+			})
+
+			// Creating the config disables a skill without touching the skill directories
+			config := &Config{Rules: Rules{Disabled: []string{"noisy"}}}
+			if err := config.Save(registry.loader.ConfigFile()); err != nil {
+				t.Fatal(err)
+			}
+
+			select {
+			case e := <-events:
+				if e.Type != EventSkillRemoved || e.Name != "noisy" {
+					t.Errorf("event = %s %s, want %s noisy", e.Type, e.Name, EventSkillRemoved)
+				}
+			case <-time.After(5 * time.Second):
+				t.Fatal("timed out waiting for the config change to be applied")
+			}
+		})
+	}
+}
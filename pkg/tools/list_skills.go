@@ -14,29 +14,31 @@ import (
 
 // ListSkillsTool allows agents to discover available skills.
 type ListSkillsTool struct {
-	registry *skillpkg.Registry
+	registry skillpkg.Catalog
 }
 
 // ListSkillsArgs defines the arguments for list_skills tool.
 type ListSkillsArgs struct {
 	// Filter optionally filters skills by keyword
 	Filter string `json:"filter,omitempty"`
+	// Query optionally ranks skills by relevance to a task description
+	Query string `json:"query,omitempty"`
 	// Source optionally filters by source (global, project)
 	Source string `json:"source,omitempty"`
 }
 
 // NewListSkillsTool creates a new list_skills tool.
-func NewListSkillsTool(registry *skillpkg.Registry) *ListSkillsTool {
+func NewListSkillsTool(registry skillpkg.Catalog) *ListSkillsTool {
 	return &ListSkillsTool{registry: registry}
 }
 
 // Info returns the tool's schema information.
 func (t *ListSkillsTool) Info(ctx context.Context) (*schema.ToolInfo, error) {
 	return &schema.ToolInfo{
-		Name: "list_skills",
+		Name: skillpkg.ToolListSkills,
 		Desc: `List all available skills with their descriptions. Use this tool to:
 - Discover what specialized capabilities are available
-- Find skills relevant to a specific domain or task
+- Find skills relevant to a specific domain or task (use query to rank by relevance)
 - Check if a skill exists before trying to use it`,
 		ParamsOneOf: schema.NewParamsOneOfByParams(map[string]*schema.ParameterInfo{
 			"filter": {
@@ -44,6 +46,11 @@ func (t *ListSkillsTool) Info(ctx context.Context) (*schema.ToolInfo, error) {
 				Desc:     "Optional: filter skills by keyword in name or description",
 				Required: false,
 			},
+			"query": {
+				Type:     schema.String,
+				Desc:     "Optional: describe the task in natural language to list only relevant skills, best match first",
+				Required: false,
+			},
 			"source": {
 				Type:     schema.String,
 				Desc:     "Optional: filter by source - 'global' or 'project'",
@@ -68,6 +75,20 @@ func (t *ListSkillsTool) InvokableRun(ctx context.Context, argumentsInJSON strin
 		return "No skills available.", nil
 	}
 
+	// Rank by relevance when a query is given
+	scores := make(map[string]float64)
+	if args.Query != "" {
+		matches, err := t.registry.Search(ctx, args.Query, 0)
+		if err != nil {
+			return "", fmt.Errorf("failed to search skills: %w", err)
+		}
+		metadata = make([]skillpkg.SkillMetadata, 0, len(matches))
+		for _, match := range matches {
+			metadata = append(metadata, match.Metadata)
+			scores[match.Metadata.Name] = match.Score
+		}
+	}
+
 	// Apply filters
 	filtered := make([]skillpkg.SkillMetadata, 0, len(metadata))
 	for _, m := range metadata {
@@ -92,7 +113,7 @@ func (t *ListSkillsTool) InvokableRun(ctx context.Context, argumentsInJSON strin
 	}
 
 	if len(filtered) == 0 {
-		if args.Filter != "" || args.Source != "" {
+		if args.Filter != "" || args.Source != "" || args.Query != "" {
 			return "No skills match the specified filters.", nil
 		}
 		return "No skills available.", nil
@@ -104,7 +125,30 @@ func (t *ListSkillsTool) InvokableRun(ctx context.Context, argumentsInJSON strin
 
 	for _, m := range filtered {
 		sb.WriteString(fmt.Sprintf("## %s\n", m.Name))
+		if score, ok := scores[m.Name]; ok {
+			sb.WriteString(fmt.Sprintf("- **Relevance**: %.2f\n", score))
+		}
 		sb.WriteString(fmt.Sprintf("- **Source**: %s\n", m.Source))
+		if versions := t.registry.Versions(m.Name); len(versions) > 1 {
+			names := make([]string, len(versions))
+			for i, v := range versions {
+				names[i] = v.Version
+				if names[i] == "" {
+					names[i] = "unversioned"
+				}
+			}
+			sb.WriteString(fmt.Sprintf("- **Versions**: %s (default: %s)\n", strings.Join(names, ", "), m.Version))
+		} else if m.Version != "" {
+			sb.WriteString(fmt.Sprintf("- **Version**: %s\n", m.Version))
+		}
+		if m.Stale {
+			sb.WriteString("- **Status**: stale (SKILL.md has errors; serving the last valid version)\n")
+		}
+		if m.Deprecated && m.ReplacedBy != "" {
+			sb.WriteString(fmt.Sprintf("- **Status**: deprecated, use %s instead\n", m.ReplacedBy))
+		} else if m.Deprecated {
+			sb.WriteString("- **Status**: deprecated\n")
+		}
 		sb.WriteString(fmt.Sprintf("- **Location**: %s/SKILL.md\n", m.Path))
 		sb.WriteString(fmt.Sprintf("- **Description**: %s\n\n", m.Description))
 	}
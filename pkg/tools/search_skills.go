@@ -0,0 +1,107 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/cloudwego/eino/components/tool"
+	"github.com/cloudwego/eino/schema"
+
+	skillpkg "github.com/dyike/eino-skills/pkg/skill"
+)
+
+// SearchSkillsTool allows agents to find knowledge inside skill bodies and references.
+type SearchSkillsTool struct {
+	registry skillpkg.Catalog
+}
+
+// SearchSkillsArgs defines the arguments for search_skills tool.
+type SearchSkillsArgs struct {
+	// Query is the text to search for
+	Query string `json:"query"`
+	// Limit optionally caps the number of hits (default 5)
+	Limit int `json:"limit,omitempty"`
+}
+
+// defaultSearchLimit is the number of hits returned when no limit is given.
+const defaultSearchLimit = 5
+
+// NewSearchSkillsTool creates a new search_skills tool.
+func NewSearchSkillsTool(registry skillpkg.Catalog) *SearchSkillsTool {
+	return &SearchSkillsTool{registry: registry}
+}
+
+// Info returns the tool's schema information.
+func (t *SearchSkillsTool) Info(ctx context.Context) (*schema.ToolInfo, error) {
+	return &schema.ToolInfo{
+		Name: skillpkg.ToolSearchSkills,
+		Desc: fmt.Sprintf(`Full-text search across the instructions (SKILL.md) and reference documents of all skills. Use this tool when:
+- %s does not reveal which skill covers a topic
+- You need a specific detail that may be buried inside a skill or its references/ files
+
+Returns ranked hits with the skill name, file, section heading and a short snippet.
+Follow up with %s(name=..., section=...) to load the exact section.`, skillpkg.ToolListSkills, skillpkg.ToolViewSkill),
+		ParamsOneOf: schema.NewParamsOneOfByParams(map[string]*schema.ParameterInfo{
+			"query": {
+				Type:     schema.String,
+				Desc:     "Words or phrase to search for (English and CJK supported)",
+				Required: true,
+			},
+			"limit": {
+				Type:     schema.Integer,
+				Desc:     "Optional: maximum number of hits to return (default 5)",
+				Required: false,
+			},
+		}),
+	}, nil
+}
+
+// InvokableRun executes the tool and returns the search hits.
+func (t *SearchSkillsTool) InvokableRun(ctx context.Context, argumentsInJSON string, opts ...tool.Option) (string, error) {
+	var args SearchSkillsArgs
+	if err := json.Unmarshal([]byte(argumentsInJSON), &args); err != nil {
+		return "", fmt.Errorf("failed to parse arguments: %w", err)
+	}
+
+	if strings.TrimSpace(args.Query) == "" {
+		return "", fmt.Errorf("query is required")
+	}
+
+	limit := args.Limit
+	if limit <= 0 {
+		limit = defaultSearchLimit
+	}
+
+	hits, err := t.registry.SearchContent(ctx, args.Query, limit)
+	if err != nil {
+		return "", fmt.Errorf("failed to search skills: %w", err)
+	}
+
+	if len(hits) == 0 {
+		return fmt.Sprintf("No matches for '%s'.", args.Query), nil
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("Found %d match(es) for '%s':\n\n", len(hits), args.Query))
+
+	for i, hit := range hits {
+		heading := hit.Heading
+		if heading == "" {
+			heading = "(top of file)"
+		}
+		sb.WriteString(fmt.Sprintf("%d. **%s** — %s › %s (score %.2f)\n", i+1, hit.Skill, hit.File, heading, hit.Score))
+		sb.WriteString(fmt.Sprintf("   > %s\n", hit.Snippet))
+		if hit.File == skillpkg.SkillFileName && hit.Heading != "" {
+			sb.WriteString(fmt.Sprintf("   Load: %s(name='%s', section='%s')\n\n", skillpkg.ToolViewSkill, hit.Skill, hit.Heading))
+		} else {
+			sb.WriteString(fmt.Sprintf("   Path: %s\n\n", hit.AbsPath))
+		}
+	}
+
+	return sb.String(), nil
+}
+
+// Ensure SearchSkillsTool implements tool.InvokableTool
+var _ tool.InvokableTool = (*SearchSkillsTool)(nil)
@@ -5,6 +5,7 @@
 //
 //   - list_skills: Discover available skills
 //   - view_skill: Load full skill content on demand
+//   - search_skills: Full-text search across skill bodies and references
 //
 // Usage:
 //
@@ -12,6 +13,9 @@
 //	registry.Initialize(ctx)
 //
 //	skillTools := tools.NewSkillTools(registry)
+//
+//	// Or limit an agent to a subset of the skills
+//	reviewerTools := tools.NewSkillTools(registry.View(skill.Filter{Tags: []string{"review"}}))
 //	agent, _ := react.NewAgent(ctx, &react.AgentConfig{
 //	    Tools: append(baseTools, skillTools...),
 //	})
@@ -25,14 +29,16 @@ import (
 
 // NewSkillTools creates all skill-related tools for an agent.
 // Returns a slice of tools that can be added to the agent's tool list.
-func NewSkillTools(registry *skillpkg.Registry) []tool.BaseTool {
+// The registry may also be a View, limiting the agent to a skill profile.
+func NewSkillTools(registry skillpkg.Catalog) []tool.BaseTool {
 	return []tool.BaseTool{
 		NewViewSkillTool(registry),
 		NewListSkillsTool(registry),
+		NewSearchSkillsTool(registry),
 	}
 }
 
 // ToolNames returns the names of all skill-related tools.
 func ToolNames() []string {
-	return []string{"view_skill", "list_skills"}
+	return []string{skillpkg.ToolViewSkill, skillpkg.ToolListSkills, skillpkg.ToolSearchSkills}
 }
@@ -5,6 +5,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strings"
 
 	"github.com/cloudwego/eino/components/tool"
 	"github.com/cloudwego/eino/schema"
@@ -14,45 +15,73 @@ import (
 
 // ViewSkillTool allows agents to load full skill content on demand.
 type ViewSkillTool struct {
-	registry *skillpkg.Registry
+	registry skillpkg.Catalog
+	// TokenCounter estimates section sizes for TOC annotations and max_tokens
+	TokenCounter skillpkg.TokenCounter
 }
 
 // ViewSkillArgs defines the arguments for view_skill tool.
 type ViewSkillArgs struct {
 	// Name is the skill name to view
 	Name string `json:"name"`
+	// Version optionally selects an installed version or semver range
+	Version string `json:"version,omitempty"`
 	// Section optionally specifies a specific section to extract
 	Section string `json:"section,omitempty"`
 	// TOC when true, returns only the table of contents (all headings)
 	TOC bool `json:"toc,omitempty"`
+	// Step optionally returns a single workflow step (1-based)
+	Step int `json:"step,omitempty"`
+	// MaxTokens optionally limits the returned content to whole sections within this budget
+	MaxTokens int `json:"max_tokens,omitempty"`
+	// Prerequisites optionally lists ("list") or includes ("inline") the skills this skill depends on
+	Prerequisites string `json:"prerequisites,omitempty"`
 }
 
+// Values of ViewSkillArgs.Prerequisites.
+const (
+	PrerequisitesList   = "list"
+	PrerequisitesInline = "inline"
+)
+
 // NewViewSkillTool creates a new view_skill tool.
-func NewViewSkillTool(registry *skillpkg.Registry) *ViewSkillTool {
-	return &ViewSkillTool{registry: registry}
+func NewViewSkillTool(registry skillpkg.Catalog) *ViewSkillTool {
+	return &ViewSkillTool{
+		registry:     registry,
+		TokenCounter: skillpkg.DefaultTokenCounter,
+	}
 }
 
 // Info returns the tool's schema information.
 func (t *ViewSkillTool) Info(ctx context.Context) (*schema.ToolInfo, error) {
 	return &schema.ToolInfo{
-		Name: "view_skill",
+		Name: skillpkg.ToolViewSkill,
 		Desc: `View the full content of a skill's instructions. Use this tool when:
-- A task matches an available skill from <available_skills>
+- A task matches one of the available skills listed in the system prompt
 - You need detailed instructions for a specific workflow
 - The skill description indicates it's relevant to the current task
 
 The tool loads the complete SKILL.md content including instructions, examples, and best practices.
 
 Usage patterns:
-1. View structure: use toc=true to see all sections (table of contents)
+1. View structure: use toc=true to see all sections with estimated token sizes
 2. View specific section: use section parameter to extract a specific part
-3. View full content: use only name parameter`,
+3. View full content: use only name parameter
+4. View a specific version: add the version parameter (e.g., version='^1.0')
+5. Limit output size: use max_tokens to return whole sections up to a token budget
+6. Follow a workflow: use step=1, then step=2, ... to load one step at a time (combine with section to pick the steps of a section)
+7. Load dependencies: use prerequisites='list' to see which skills this one builds on, or prerequisites='inline' to include their full content first`,
 		ParamsOneOf: schema.NewParamsOneOfByParams(map[string]*schema.ParameterInfo{
 			"name": {
 				Type:     schema.String,
-				Desc:     "The name of the skill to view (must match a name from <available_skills>)",
+				Desc:     "The name of the skill to view (must match an available skill name)",
 				Required: true,
 			},
+			"version": {
+				Type:     schema.String,
+				Desc:     "Optional: skill version or semver range (e.g., '1.2.0', '^1.0'). Defaults to the pinned or newest version",
+				Required: false,
+			},
 			"section": {
 				Type:     schema.String,
 				Desc:     "Optional: extract only a specific section by heading (e.g., 'Instructions', 'Examples')",
@@ -60,7 +89,23 @@ Usage patterns:
 			},
 			"toc": {
 				Type:     schema.Boolean,
-				Desc:     "Optional: when true, returns only the table of contents (all headings with indentation)",
+				Desc:     "Optional: when true, returns only the table of contents (all headings with indentation and estimated token counts)",
+				Required: false,
+			},
+			"step": {
+				Type:     schema.Integer,
+				Desc:     "Optional: return only the Nth workflow step (1-based) with its commands, plus the total step count",
+				Required: false,
+			},
+			"max_tokens": {
+				Type:     schema.Integer,
+				Desc:     "Optional: maximum number of tokens to return; content is cut at a section boundary and a truncation marker lists the omitted sections",
+				Required: false,
+			},
+			"prerequisites": {
+				Type:     schema.String,
+				Desc:     "Optional: 'list' names the skills this skill depends on, in load order; 'inline' prepends their full content and cannot be combined with max_tokens",
+				Enum:     []string{PrerequisitesList, PrerequisitesInline},
 				Required: false,
 			},
 		}),
@@ -83,17 +128,65 @@ func (t *ViewSkillTool) InvokableRun(ctx context.Context, argumentsInJSON string
 		return "", fmt.Errorf("cannot specify both 'toc' and 'section' parameters")
 	}
 
+	if args.TOC && args.Step != 0 {
+		return "", fmt.Errorf("cannot specify both 'toc' and 'step' parameters")
+	}
+
+	if args.Step < 0 {
+		return "", fmt.Errorf("step must be a positive number")
+	}
+
+	if args.MaxTokens < 0 {
+		return "", fmt.Errorf("max_tokens must not be negative")
+	}
+
+	switch args.Prerequisites {
+	case "", PrerequisitesList:
+	case PrerequisitesInline:
+		if args.TOC || args.Step != 0 || args.MaxTokens != 0 {
+			return "", fmt.Errorf("cannot combine prerequisites='inline' with 'toc', 'step' or 'max_tokens'")
+		}
+	default:
+		return "", fmt.Errorf("prerequisites must be '%s' or '%s'", PrerequisitesList, PrerequisitesInline)
+	}
+
+	ref := args.Name
+	if args.Version != "" {
+		ref = args.Name + "@" + args.Version
+	}
+
+	// Deprecated skills redirect to their replacement
+	ref, notice := t.redirect(ref)
+
 	// Load skill content
-	content, err := t.registry.GetContent(ctx, args.Name)
+	content, err := t.registry.GetContent(ctx, ref)
+	if err != nil {
+		return "", fmt.Errorf("failed to load skill '%s': %w", ref, err)
+	}
+
+	prefix := notice
+	if args.Prerequisites != "" {
+		prereqs, err := t.prerequisites(ctx, ref, args.Prerequisites)
+		if err != nil {
+			return "", err
+		}
+		prefix += prereqs
+	}
+
+	result, err := t.view(content, ref, args)
 	if err != nil {
-		return "", fmt.Errorf("failed to load skill '%s': %w", args.Name, err)
+		return "", err
 	}
+	return prefix + result, nil
+}
 
+// view extracts the requested part of a skill's content.
+func (t *ViewSkillTool) view(content, ref string, args ViewSkillArgs) (string, error) {
 	parser := skillpkg.NewParser()
 
 	// Extract TOC if requested
 	if args.TOC {
-		toc := parser.ExtractTOC(content)
+		toc := parser.ExtractTOCWithTokens(content, t.TokenCounter)
 		if toc == "" {
 			return "No headings found in this skill.", nil
 		}
@@ -104,12 +197,128 @@ func (t *ViewSkillTool) InvokableRun(ctx context.Context, argumentsInJSON string
 	if args.Section != "" {
 		sectionContent := parser.ExtractSection(content, args.Section)
 		if sectionContent == "" {
-			return "", fmt.Errorf("section '%s' not found in skill '%s'", args.Section, args.Name)
+			return "", fmt.Errorf("section '%s' not found in skill '%s'", args.Section, ref)
 		}
-		return sectionContent, nil
+		content = sectionContent
 	}
 
-	return content, nil
+	// Extract a single workflow step if requested
+	if args.Step > 0 {
+		steps := parser.ExtractSteps(content)
+		if len(steps) == 0 {
+			return "", fmt.Errorf("no workflow steps found in skill '%s'", ref)
+		}
+		if args.Step > len(steps) {
+			return "", fmt.Errorf("step %d out of range: skill '%s' has %d step(s)", args.Step, ref, len(steps))
+		}
+		return t.truncate(parser, formatStep(steps[args.Step-1], len(steps)), args.MaxTokens), nil
+	}
+
+	return t.truncate(parser, content, args.MaxTokens), nil
+}
+
+// maxRedirects bounds replaced-by chains, which may form cycles.
+const maxRedirects = 8
+
+// redirect follows the replaced-by references of a deprecated skill to the
+// first replacement that is not deprecated, returning the reference to view
+// and a notice for the model. Other references are returned unchanged.
+func (t *ViewSkillTool) redirect(ref string) (string, string) {
+	m, err := t.registry.Resolve(ref)
+	if err != nil || !m.Deprecated {
+		return ref, ""
+	}
+
+	deprecated := m
+	for range maxRedirects {
+		if !m.Deprecated || m.ReplacedBy == "" {
+			break
+		}
+		next, err := t.registry.Resolve(m.ReplacedBy)
+		if err != nil {
+			return ref, fmt.Sprintf("[Skill '%s' is deprecated; its replacement '%s' is not available.]\n\n", deprecated.Name, m.ReplacedBy)
+		}
+		m = next
+	}
+
+	if m.Name == deprecated.Name {
+		return ref, fmt.Sprintf("[Skill '%s' is deprecated.]\n\n", deprecated.Name)
+	}
+	return m.Ref(), fmt.Sprintf("[Skill '%s' is deprecated and has been replaced by '%s'. Showing '%s' instead; use '%s' from now on.]\n\n",
+		deprecated.Name, m.Name, m.Name, m.Name)
+}
+
+// prerequisites lists the skills a skill depends on, or renders their
+// content in load order.
+func (t *ViewSkillTool) prerequisites(ctx context.Context, ref, mode string) (string, error) {
+	prereqs, err := t.registry.Prerequisites(ref)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve prerequisites of '%s': %w", ref, err)
+	}
+	if len(prereqs) == 0 {
+		return "", nil
+	}
+
+	var sb strings.Builder
+	if mode == PrerequisitesList {
+		refs := make([]string, len(prereqs))
+		for i, m := range prereqs {
+			refs[i] = m.Ref()
+		}
+		sb.WriteString(fmt.Sprintf("[Prerequisites, in load order: %s. Load them with %s before following this skill.]\n\n", strings.Join(refs, ", "), skillpkg.ToolViewSkill))
+		return sb.String(), nil
+	}
+
+	for _, m := range prereqs {
+		content, err := t.registry.GetContent(ctx, m.Ref())
+		if err != nil {
+			return "", fmt.Errorf("failed to load prerequisite '%s': %w", m.Ref(), err)
+		}
+		sb.WriteString(fmt.Sprintf("<prerequisite name=\"%s\">\n%s\n</prerequisite>\n\n", m.Ref(), content))
+	}
+	return sb.String(), nil
+}
+
+// formatStep renders a workflow step with its position and a pointer to the next one.
+func formatStep(step skillpkg.Step, total int) string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("## Step %d of %d: %s\n", step.Number, total, step.Title))
+
+	if step.Prose != "" {
+		sb.WriteString("\n")
+		sb.WriteString(step.Prose)
+		sb.WriteString("\n")
+	}
+
+	for _, block := range step.Code {
+		sb.WriteString(fmt.Sprintf("\n```%s\n%s\n```\n", block.Language, block.Code))
+	}
+
+	if step.Number < total {
+		sb.WriteString(fmt.Sprintf("\n[Complete this step, then request step=%d.]", step.Number+1))
+	} else {
+		sb.WriteString("\n[This is the last step.]")
+	}
+
+	return sb.String()
+}
+
+// truncate limits content to maxTokens and appends a marker naming the omitted sections.
+func (t *ViewSkillTool) truncate(parser *skillpkg.Parser, content string, maxTokens int) string {
+	if maxTokens == 0 {
+		return content
+	}
+
+	kept, omitted := parser.TruncateSections(content, maxTokens, t.TokenCounter)
+	if len(omitted) == 0 && kept == content {
+		return content
+	}
+
+	marker := fmt.Sprintf("\n\n[... truncated to ~%d tokens", maxTokens)
+	if len(omitted) > 0 {
+		marker += fmt.Sprintf("; omitted sections: %s. Use the section parameter to load them", strings.Join(omitted, ", "))
+	}
+	return kept + marker + " ...]"
 }
 
 // Ensure ViewSkillTool implements tool.InvokableTool